@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"regexp"
 	"strings"
 	"testing"
 	"time"
@@ -99,6 +100,51 @@ func TestMetricsHandler(t *testing.T) {
 		t.Errorf("Unexpected value for eseries_exporter_collect_error")
 	}
 
+	if !strings.Contains(body, "# TYPE eseries_drive_status gauge") {
+		t.Errorf("Unexpected type for eseries_drive_status in classic format")
+	}
+
+	body, err = queryExporterOpenMetrics("target=test1")
+	if err != nil {
+		t.Fatalf("Unexpected error GET /eseries: %s", err.Error())
+	}
+	if !strings.Contains(body, "# TYPE eseries_drive_status stateset") {
+		t.Errorf("Unexpected type for eseries_drive_status in OpenMetrics format")
+	}
+	if !strings.Contains(body, `eseries_drive_status{slot="58",eseries_drive_status="optimal",tray="0"} 1.0`) {
+		t.Errorf("Unexpected value for eseries_drive_status in OpenMetrics format:\n%s", body)
+	}
+	if !strings.Contains(body, "# UNIT eseries_exporter_collector_duration_seconds seconds") {
+		t.Errorf("Missing unit for eseries_exporter_collector_duration_seconds")
+	}
+	if !strings.HasSuffix(body, "# EOF\n") {
+		t.Errorf("Missing EOF in OpenMetrics format")
+	}
+
+	body, err = queryExporter("target=test1&format=influx", http.StatusOK)
+	if err != nil {
+		t.Fatalf("Unexpected error GET /eseries: %s", err.Error())
+	}
+	if !regexp.MustCompile(`(?m)^eseries_drive,slot=58,status=optimal,tray=0 value=1 [0-9]+$`).MatchString(body) {
+		t.Errorf("Unexpected value for eseries_drive status in influx format:\n%s", body)
+	}
+
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("http://%s/eseries?target=test1&format=influx", address), nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+	req.Header.Set("Accept-Encoding", "identity")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Unexpected error GET /eseries: %s", err.Error())
+	}
+	resp.Body.Close()
+	if encoding := resp.Header.Get("Content-Encoding"); encoding != "" {
+		t.Errorf("Unexpected Content-Encoding %s for identity", encoding)
+	}
+
+	_, _ = queryExporter("target=test1&format=json", http.StatusBadRequest)
+
 	_, _ = queryExporter("target=test1&module=ssl-error", http.StatusBadRequest)
 
 	_, _ = queryExporter("", http.StatusBadRequest)
@@ -106,6 +152,23 @@ func TestMetricsHandler(t *testing.T) {
 	_, _ = queryExporter("module=dne", http.StatusNotFound)
 }
 
+func TestAcceptsGzip(t *testing.T) {
+	for header, expected := range map[string]bool{
+		"":                        false,
+		"identity":                false,
+		"gzip":                    true,
+		"deflate, gzip;q=0.5":     true,
+		"gzip;q=0":                false,
+		"*":                       true,
+		"zstd, gzip ; q=1.0, br":  true,
+		"identity;q=1, *;q=0.000": false,
+	} {
+		if got := acceptsGzip(header); got != expected {
+			t.Errorf("Unexpected acceptsGzip(%q) %v, expected %v", header, got, expected)
+		}
+	}
+}
+
 func queryExporter(param string, want int) (string, error) {
 	resp, err := http.Get(fmt.Sprintf("http://%s/eseries?%s", address, param))
 	if err != nil {
@@ -123,3 +186,73 @@ func queryExporter(param string, want int) (string, error) {
 	}
 	return string(b), nil
 }
+
+func queryExporterOpenMetrics(param string) (string, error) {
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("http://%s/eseries?%s", address, param), nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Accept", "application/openmetrics-text;version=1.0.0")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if contentType := resp.Header.Get("Content-Type"); !strings.HasPrefix(contentType, "application/openmetrics-text") {
+		return "", fmt.Errorf("unexpected Content-Type %s", contentType)
+	}
+	// The transport asks for gzip and decompresses the body transparently
+	if !resp.Uncompressed {
+		return "", fmt.Errorf("response was not gzip compressed")
+	}
+	b, err := io.ReadAll(resp.Body)
+	return string(b), err
+}
+
+func TestOTLPGatherCreatedTimestamps(t *testing.T) {
+	fixtures := make(map[string][]byte)
+	for path, file := range map[string]string{
+		"hardware-inventory":             "../../internal/collectors/testdata/controllers.json",
+		"analyzed/controller-statistics": "../../internal/collectors/testdata/analysed-controller-statistics.json",
+		"controller-statistics":          "../../internal/collectors/testdata/controller-statistics.json",
+	} {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatalf("Error loading fixture data: %s", err.Error())
+		}
+		fixtures[path] = data
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = rw.Write(fixtures[strings.TrimPrefix(req.URL.Path, "/devmgr/v2/storage-systems/test/")])
+	}))
+	defer server.Close()
+	c := &config.Config{
+		Modules: map[string]*config.Module{"default": {
+			User:       "test",
+			Password:   "test",
+			Collectors: []string{"controller-statistics"},
+			ProxyURL:   server.URL,
+		}},
+		OTLP: &config.OTLP{Targets: []config.OTLPTarget{{Target: "test", Module: "default"}}},
+	}
+	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+	batches := otlpGather(c, logger)()
+	if len(batches) != 1 {
+		t.Fatalf("Unexpected batches %d, expected 1", len(batches))
+	}
+	var found bool
+	for _, family := range batches[0].Families {
+		if family.GetName() != "eseries_controller_iops_total" {
+			continue
+		}
+		found = true
+		for _, m := range family.GetMetric() {
+			if m.GetCounter().GetCreatedTimestamp() == nil {
+				t.Errorf("Missing created timestamp on %s", family.GetName())
+			}
+		}
+	}
+	if !found {
+		t.Errorf("Missing eseries_controller_iops_total")
+	}
+}
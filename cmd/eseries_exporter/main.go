@@ -1,24 +1,33 @@
 package main
 
 import (
+	"bytes"
+	"compress/gzip"
+	"context"
 	"crypto/tls"
 	"crypto/x509"
 	"fmt"
+	"io"
 	"log/slog"
 	"net/http"
 	"net/url"
 	"os"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/alecthomas/kingpin/v2"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
 	"github.com/prometheus/common/version"
 	"github.com/prometheus/exporter-toolkit/web"
 	"github.com/prometheus/exporter-toolkit/web/kingpinflag"
 
 	collector "github.com/sckyzo/eseries_exporter/internal/collectors"
 	"github.com/sckyzo/eseries_exporter/internal/config"
+	"github.com/sckyzo/eseries_exporter/internal/otlp"
 )
 
 var (
@@ -26,6 +35,9 @@ var (
 	webConfig  = kingpinflag.AddFlags(kingpin.CommandLine, ":9313")
 	logLevel   = kingpin.Flag("log.level", "Log level (debug, info, warn, error)").Default("info").String()
 	logFormat  = kingpin.Flag("log.format", "Log format (text, json)").Default("text").String()
+
+	capacityHistoryFile = kingpin.Flag("capacity.history-file",
+		"Path to the file persisting pool usage history for the capacity-forecast collector, empty keeps it in memory").Default("").String()
 )
 
 func metricsHandler(c *config.Config, logger *slog.Logger) http.HandlerFunc {
@@ -52,57 +64,196 @@ func metricsHandler(c *config.Config, logger *slog.Logger) http.HandlerFunc {
 			return
 		}
 
-		target := config.Target{
-			Name:       t,
-			User:       module.User,
-			Password:   module.Password,
-			ProxyURL:   module.ProxyURL,
-			BaseURL:    proxyURL,
-			Collectors: module.Collectors,
+		influx := false
+		switch f := r.URL.Query().Get("format"); f {
+		case "":
+		case "influx":
+			influx = true
+		default:
+			http.Error(w, fmt.Sprintf("Unknown format %s", f), http.StatusBadRequest)
+			return
 		}
 
-		httpClient := &http.Client{
-			Timeout: time.Duration(module.Timeout) * time.Second,
+		format := expfmt.NegotiateIncludingOpenMetrics(r.Header)
+		target, err := newTarget(t, module, proxyURL, logger)
+		if err != nil {
+			http.Error(w, "Error loading root CA", http.StatusBadRequest)
+			return
 		}
+		target.OpenMetrics = !influx && format.FormatType() == expfmt.TypeOpenMetrics
+		target.CreatedTimestamps = target.OpenMetrics
+		gatherer := newGatherer(target, logger)
 
-		if proxyURL.Scheme == "https" {
-			logger.Debug("Setting up SSL transport", "url", module.ProxyURL)
-			rootCAs, err := x509.SystemCertPool()
-			if err != nil {
-				logger.Error("Error loading system cert pool, creating empty cert pool", "error", err)
-				rootCAs = x509.NewCertPool()
+		if influx {
+			scrapeTime := time.Now()
+			writeMetrics(w, r, gatherer, "text/plain; charset=utf-8", func(w io.Writer, families []*dto.MetricFamily) error {
+				return collector.WriteInflux(w, families, scrapeTime)
+			}, logger)
+			return
+		}
+
+		// OpenMetrics is encoded by the collectors so status and identity families
+		// can be exposed as StateSet and Info, the classic format is left to promhttp
+		if target.OpenMetrics {
+			writeMetrics(w, r, gatherer, string(format), collector.WriteOpenMetrics, logger)
+			return
+		}
+		h := promhttp.HandlerFor(gatherer, promhttp.HandlerOpts{})
+		h.ServeHTTP(w, r)
+	}
+}
+
+// writeMetrics serves the families gathered by gatherer in a format promhttp
+// cannot encode. Like promhttp, gathering or encoding errors are answered with
+// a 500 and the response is gzip compressed when the client accepts it.
+func writeMetrics(w http.ResponseWriter, r *http.Request, gatherer prometheus.Gatherer, contentType string,
+	encode func(io.Writer, []*dto.MetricFamily) error, logger *slog.Logger) {
+	families, err := gatherer.Gather()
+	if err != nil {
+		logger.Error("Error gathering metrics", "error", err)
+		http.Error(w, "Error gathering metrics", http.StatusInternalServerError)
+		return
+	}
+	var buf bytes.Buffer
+	if err := encode(&buf, families); err != nil {
+		logger.Error("Error encoding metrics", "error", err)
+		http.Error(w, "Error encoding metrics", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Add("Vary", "Accept-Encoding")
+	if !acceptsGzip(r.Header.Get("Accept-Encoding")) {
+		if _, err := w.Write(buf.Bytes()); err != nil {
+			logger.Error("Error writing metrics", "error", err)
+		}
+		return
+	}
+	w.Header().Set("Content-Encoding", "gzip")
+	gz := gzip.NewWriter(w)
+	_, err = gz.Write(buf.Bytes())
+	if closeErr := gz.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		logger.Error("Error writing metrics", "error", err)
+	}
+}
+
+// acceptsGzip reports whether an Accept-Encoding header accepts gzip, either
+// by name or through a wildcard, with a non-zero quality.
+func acceptsGzip(header string) bool {
+	for _, coding := range strings.Split(header, ",") {
+		name, params, _ := strings.Cut(coding, ";")
+		name = strings.TrimSpace(name)
+		if name != "gzip" && name != "*" {
+			continue
+		}
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if quality, err := strconv.ParseFloat(q, 64); err == nil && quality == 0 {
+				continue
 			}
-			if module.RootCA != "" {
-				certs, err := os.ReadFile(module.RootCA)
-				if err != nil {
-					logger.Error("Error loading root CA", "rootCA", module.RootCA, "error", err)
-					http.Error(w, "Error loading root CA", http.StatusBadRequest)
-					return
-				}
-				if ok := rootCAs.AppendCertsFromPEM(certs); !ok {
-					logger.Error("Error appending root CA to pool", "rootCA", module.RootCA)
-				}
+		}
+		return true
+	}
+	return false
+}
+
+// newTarget builds the target name collected with module.
+func newTarget(name string, module *config.Module, proxyURL *url.URL, logger *slog.Logger) (config.Target, error) {
+	target := config.Target{
+		Name:                     name,
+		User:                     module.User,
+		Password:                 module.Password,
+		ProxyURL:                 module.ProxyURL,
+		BaseURL:                  proxyURL,
+		Collectors:               module.Collectors,
+		ComponentStatuses:        module.ComponentStatuses,
+		CapacityForecastLookback: module.CapacityForecastLookback,
+		StatisticsTimestamps:     module.StatisticsTimestamps,
+		StatisticsMaxAge:         module.StatisticsMaxAge,
+		DriveOutlierThreshold:    module.DriveOutlierThreshold,
+		ProxyConcurrency:         module.ProxyConcurrency,
+	}
+
+	httpClient := &http.Client{
+		Timeout: time.Duration(module.Timeout) * time.Second,
+	}
+
+	if proxyURL.Scheme == "https" {
+		logger.Debug("Setting up SSL transport", "url", module.ProxyURL)
+		rootCAs, err := x509.SystemCertPool()
+		if err != nil {
+			logger.Error("Error loading system cert pool, creating empty cert pool", "error", err)
+			rootCAs = x509.NewCertPool()
+		}
+		if module.RootCA != "" {
+			certs, err := os.ReadFile(module.RootCA)
+			if err != nil {
+				logger.Error("Error loading root CA", "rootCA", module.RootCA, "error", err)
+				return target, err
 			}
-			tlsConfig := &tls.Config{
-				InsecureSkipVerify: module.InsecureSSL,
-				RootCAs:            rootCAs,
+			if ok := rootCAs.AppendCertsFromPEM(certs); !ok {
+				logger.Error("Error appending root CA to pool", "rootCA", module.RootCA)
 			}
-			httpClient.Transport = &http.Transport{TLSClientConfig: tlsConfig}
 		}
-		target.HttpClient = httpClient
+		tlsConfig := &tls.Config{
+			InsecureSkipVerify: module.InsecureSSL,
+			RootCAs:            rootCAs,
+		}
+		httpClient.Transport = &http.Transport{TLSClientConfig: tlsConfig}
+	}
+	target.HttpClient = httpClient
+	return target, nil
+}
 
-		registry := prometheus.NewRegistry()
-		eseriesCollector := collector.NewCollector(target, logger)
+// newGatherer returns the gatherer running the target's collectors, against every
+// storage system of the proxy for target=*.
+func newGatherer(target config.Target, logger *slog.Logger) prometheus.Gatherer {
+	if target.Name == collector.ProxyTarget {
+		return collector.ProxyGatherer(target, logger)
+	}
+	registry := prometheus.NewRegistry()
+	eseriesCollector := collector.NewCollector(target, logger)
 
-		// Register all sub-collectors
-		for _, col := range eseriesCollector.Collectors {
-			if err := registry.Register(col); err != nil {
-				logger.Error("Collector registration failed", "collector", col, "error", err)
-			}
+	// Register all sub-collectors
+	for _, col := range eseriesCollector.Collectors {
+		if err := registry.Register(col); err != nil {
+			logger.Error("Collector registration failed", "collector", col, "error", err)
 		}
+	}
+	return registry
+}
 
-		h := promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
-		h.ServeHTTP(w, r)
+// otlpGather returns the function gathering the OTLP targets for each push.
+func otlpGather(c *config.Config, logger *slog.Logger) func() []otlp.Batch {
+	return func() []otlp.Batch {
+		batches := make([]otlp.Batch, 0, len(c.OTLP.Targets))
+		for _, t := range c.OTLP.Targets {
+			module := c.Modules[t.Module]
+			proxyURL, err := url.Parse(module.ProxyURL)
+			if err != nil {
+				logger.Error("Unable to parse ProxyURL", "url", module.ProxyURL, "error", err)
+				continue
+			}
+			target, err := newTarget(t.Target, module, proxyURL, logger)
+			if err != nil {
+				continue
+			}
+			// Cumulative sums take their start time from the counters' created timestamp
+			target.CreatedTimestamps = true
+			families, err := newGatherer(target, logger).Gather()
+			if err != nil {
+				logger.Error("Error gathering metrics", "target", t.Target, "error", err)
+				continue
+			}
+			attributes := map[string]string{"eseries.module": t.Module}
+			if t.Target != collector.ProxyTarget {
+				attributes["eseries.system.id"] = t.Target
+			}
+			batches = append(batches, otlp.Batch{Attributes: attributes, Families: families})
+		}
+		return batches
 	}
 }
 
@@ -143,6 +294,15 @@ func main() {
 		logger.Error("Error loading config", "file", *configFile, "error", err)
 		os.Exit(1)
 	}
+	if err := collector.SetCapacityHistoryFile(*capacityHistoryFile); err != nil {
+		logger.Error("Error loading capacity history", "file", *capacityHistoryFile, "error", err)
+		os.Exit(1)
+	}
+
+	if sc.C.OTLP != nil {
+		logger.Info("Pushing to OTLP endpoint", "endpoint", sc.C.OTLP.Endpoint, "interval", sc.C.OTLP.Interval)
+		go otlp.NewExporter(sc.C.OTLP, logger).Run(context.Background(), otlpGather(sc.C, logger))
+	}
 
 	http.Handle("/metrics", promhttp.Handler())
 	http.Handle("/eseries", metricsHandler(sc.C, logger))
@@ -0,0 +1,267 @@
+package collector
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/sckyzo/eseries_exporter/internal/config"
+)
+
+// capacityHistorySamples bounds the samples kept per pool, scrapes closer than
+// lookback/capacityHistorySamples to the previous sample are not recorded.
+const capacityHistorySamples = 1000
+
+var poolHistory = &capacityHistory{Pools: make(map[string][]capacitySample)}
+
+type capacitySample struct {
+	// Time is a Unix timestamp in seconds
+	Time     int64   `json:"time"`
+	Used     float64 `json:"used"`
+	Capacity float64 `json:"capacity"`
+}
+
+// capacityHistory holds pool usage samples keyed by target and pool, optionally
+// persisted to a file so forecasts survive exporter restarts.
+type capacityHistory struct {
+	sync.Mutex
+	path  string
+	Pools map[string][]capacitySample `json:"pools"`
+}
+
+// SetCapacityHistoryFile loads the pool usage history from path and persists
+// it there from now on. An empty path keeps the history in memory only.
+func SetCapacityHistoryFile(path string) error {
+	return poolHistory.load(path)
+}
+
+func (h *capacityHistory) load(path string) error {
+	h.Lock()
+	defer h.Unlock()
+	h.path = path
+	if path == "" {
+		return nil
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	var stored struct {
+		Pools map[string][]capacitySample `json:"pools"`
+	}
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return fmt.Errorf("failed to unmarshal capacity history %s: %w", path, err)
+	}
+	if stored.Pools != nil {
+		h.Pools = stored.Pools
+	}
+	return nil
+}
+
+// save writes the history to a temporary file renamed over the history file
+// so an interrupted write never leaves a truncated history. Callers hold the lock.
+func (h *capacityHistory) save() error {
+	if h.path == "" {
+		return nil
+	}
+	data, err := json.Marshal(h)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(h.path), filepath.Base(h.path)+".*")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = os.Remove(tmp.Name())
+	}()
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), h.path)
+}
+
+// record adds s to the history of key, drops samples older than lookback and
+// returns the remaining samples. The history is saved when it changed.
+func (h *capacityHistory) record(key string, s capacitySample, lookback time.Duration) ([]capacitySample, error) {
+	h.Lock()
+	defer h.Unlock()
+	samples := h.Pools[key]
+	changed := false
+	interval := int64(lookback.Seconds() / capacityHistorySamples)
+	if len(samples) == 0 || s.Time-samples[len(samples)-1].Time >= interval {
+		samples = append(samples, s)
+		changed = true
+	}
+	cutoff := s.Time - int64(lookback.Seconds())
+	i := 0
+	for i < len(samples) && samples[i].Time < cutoff {
+		i++
+	}
+	if i > 0 {
+		samples = append([]capacitySample{}, samples[i:]...)
+		changed = true
+	}
+	h.Pools[key] = samples
+	if !changed {
+		return samples, nil
+	}
+	return samples, h.save()
+}
+
+// prune drops the history of the pools of target that are not in seen, such as
+// deleted pools. The history is saved when it changed.
+func (h *capacityHistory) prune(target string, seen map[string]bool) error {
+	h.Lock()
+	defer h.Unlock()
+	changed := false
+	for key := range h.Pools {
+		if strings.HasPrefix(key, target+"/") && !seen[key] {
+			delete(h.Pools, key)
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+	return h.save()
+}
+
+// forecastCapacity fits a least squares line through the used bytes of samples.
+// It returns the growth rate in bytes per second and the time in seconds until
+// the latest capacity is used up, which is only valid while the pool grows.
+func forecastCapacity(samples []capacitySample) (growth float64, untilFull float64, growing bool, ok bool) {
+	if len(samples) < 2 || samples[len(samples)-1].Time == samples[0].Time {
+		return 0, 0, false, false
+	}
+	// Times are relative to the first sample to keep the sums well conditioned
+	n := float64(len(samples))
+	var sumX, sumY, sumXY, sumXX float64
+	for _, s := range samples {
+		x := float64(s.Time - samples[0].Time)
+		sumX += x
+		sumY += s.Used
+		sumXY += x * s.Used
+		sumXX += x * x
+	}
+	denominator := n*sumXX - sumX*sumX
+	if denominator == 0 {
+		return 0, 0, false, false
+	}
+	growth = (n*sumXY - sumX*sumY) / denominator
+	if growth <= 0 {
+		return growth, 0, false, true
+	}
+	latest := samples[len(samples)-1]
+	free := latest.Capacity - latest.Used
+	if free < 0 {
+		free = 0
+	}
+	return growth, free / growth, true, true
+}
+
+type CapacityForecastCollector struct {
+	Growth    *prometheus.Desc
+	UntilFull *prometheus.Desc
+	Window    *prometheus.Desc
+	target    config.Target
+	logger    *slog.Logger
+}
+
+func init() {
+	registerCollector("capacity-forecast", false, NewCapacityForecastExporter)
+}
+
+func NewCapacityForecastExporter(target config.Target, logger *slog.Logger) Collector {
+	labels := []string{"pool"}
+	return &CapacityForecastCollector{
+		Growth: prometheus.NewDesc(prometheus.BuildFQName(namespace, "pool", "used_growth_bytes_per_second"),
+			"Growth rate of the storage pool used capacity over the forecast lookback window", labels, nil),
+		UntilFull: prometheus.NewDesc(prometheus.BuildFQName(namespace, "pool", "time_until_full_seconds"),
+			"Estimated time until the storage pool is full at the current growth rate, absent when the pool is not growing", labels, nil),
+		Window: prometheus.NewDesc(prometheus.BuildFQName(namespace, "pool", "forecast_window_seconds"),
+			"Time span of the usage history the storage pool forecast is based on", labels, nil),
+		target: target,
+		logger: logger,
+	}
+}
+
+func (c *CapacityForecastCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.Growth
+	ch <- c.UntilFull
+	ch <- c.Window
+}
+
+func (c *CapacityForecastCollector) Collect(ch chan<- prometheus.Metric) {
+	c.logger.Debug("Collecting capacity forecast metrics")
+	collectTime := time.Now()
+	var errorMetric int
+	pools, err := c.collect()
+	if err != nil {
+		c.logger.Error("Collection failed", "error", err)
+		errorMetric = 1
+	}
+
+	lookback := c.target.CapacityForecastLookback
+	if lookback <= 0 {
+		lookback = config.DefaultCapacityForecastLookback
+	}
+	now := timeNow().Unix()
+	seen := make(map[string]bool)
+	for _, pool := range pools {
+		used, _ := strconv.ParseFloat(pool.UsedSpace, 64)
+		capacity, _ := strconv.ParseFloat(pool.TotalRaidedSpace, 64)
+		key := c.target.Name + "/" + pool.ID
+		seen[key] = true
+		samples, err := poolHistory.record(key, capacitySample{Time: now, Used: used, Capacity: capacity}, lookback)
+		if err != nil {
+			c.logger.Error("Unable to save capacity history", "error", err)
+		}
+		growth, untilFull, growing, ok := forecastCapacity(samples)
+		if !ok {
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(c.Growth, prometheus.GaugeValue, growth, pool.Label)
+		if growing {
+			ch <- prometheus.MustNewConstMetric(c.UntilFull, prometheus.GaugeValue, untilFull, pool.Label)
+		}
+		window := float64(samples[len(samples)-1].Time - samples[0].Time)
+		ch <- prometheus.MustNewConstMetric(c.Window, prometheus.GaugeValue, window, pool.Label)
+	}
+	// A failed scrape lists no pools, only a complete one shows which were deleted
+	if err == nil {
+		if err := poolHistory.prune(c.target.Name, seen); err != nil {
+			c.logger.Error("Unable to save capacity history", "error", err)
+		}
+	}
+
+	ch <- prometheus.MustNewConstMetric(collectError, prometheus.GaugeValue, float64(errorMetric), "capacity-forecast")
+	ch <- prometheus.MustNewConstMetric(collectDuration, prometheus.GaugeValue, time.Since(collectTime).Seconds(), "capacity-forecast")
+}
+
+func (c *CapacityForecastCollector) collect() ([]StoragePool, error) {
+	body, err := getRequest(c.target, fmt.Sprintf("/devmgr/v2/storage-systems/%s/storage-pools", c.target.Name), c.logger)
+	if err != nil {
+		return nil, err
+	}
+	var pools []StoragePool
+	if err := json.Unmarshal(body, &pools); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal storage pools: %w", err)
+	}
+	return pools, nil
+}
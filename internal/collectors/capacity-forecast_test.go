@@ -0,0 +1,258 @@
+package collector
+
+import (
+	"log/slog"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/sckyzo/eseries_exporter/internal/config"
+)
+
+// linearSeries returns hourly samples growing by growth bytes per second from used.
+func linearSeries(hours int, used float64, growth float64, capacity float64) []capacitySample {
+	samples := make([]capacitySample, 0, hours)
+	for i := 0; i < hours; i++ {
+		samples = append(samples, capacitySample{
+			Time:     1700000000 + int64(i*3600),
+			Used:     used + growth*float64(i*3600),
+			Capacity: capacity,
+		})
+	}
+	return samples
+}
+
+func TestForecastCapacity(t *testing.T) {
+	tests := []struct {
+		Name      string
+		Samples   []capacitySample
+		Growth    float64
+		UntilFull float64
+		Growing   bool
+		OK        bool
+	}{
+		{
+			Name:      "linear growth",
+			Samples:   linearSeries(24, 1000, 10, 2000000),
+			Growth:    10,
+			UntilFull: (2000000 - (1000 + 10*23*3600)) / 10.0,
+			Growing:   true,
+			OK:        true,
+		},
+		{
+			Name:    "flat",
+			Samples: linearSeries(24, 1000, 0, 2000000),
+			OK:      true,
+		},
+		{
+			Name:    "shrinking",
+			Samples: linearSeries(24, 1000000, -5, 2000000),
+			Growth:  -5,
+			OK:      true,
+		},
+		{
+			Name:      "full",
+			Samples:   linearSeries(24, 1000, 100, 1000),
+			Growth:    100,
+			UntilFull: 0,
+			Growing:   true,
+			OK:        true,
+		},
+		{
+			Name:    "single sample",
+			Samples: linearSeries(1, 1000, 10, 2000000),
+		},
+		{
+			Name: "same time",
+			Samples: []capacitySample{
+				{Time: 1700000000, Used: 1000, Capacity: 2000000},
+				{Time: 1700000000, Used: 2000, Capacity: 2000000},
+			},
+		},
+	}
+	for _, test := range tests {
+		growth, untilFull, growing, ok := forecastCapacity(test.Samples)
+		if ok != test.OK || growing != test.Growing {
+			t.Errorf("%s: unexpected ok=%v growing=%v, expected ok=%v growing=%v", test.Name, ok, growing, test.OK, test.Growing)
+			continue
+		}
+		if math.Abs(growth-test.Growth) > 1e-9 {
+			t.Errorf("%s: unexpected growth %v, expected %v", test.Name, growth, test.Growth)
+		}
+		if math.Abs(untilFull-test.UntilFull) > 1e-6 {
+			t.Errorf("%s: unexpected time until full %v, expected %v", test.Name, untilFull, test.UntilFull)
+		}
+	}
+}
+
+func TestForecastCapacityNoisy(t *testing.T) {
+	samples := linearSeries(48, 1e12, 1e6, 1e13)
+	// Alternate the noise so it cancels out over the window
+	for i := range samples {
+		if i%2 == 0 {
+			samples[i].Used += 5e8
+		} else {
+			samples[i].Used -= 5e8
+		}
+	}
+	growth, _, growing, ok := forecastCapacity(samples)
+	if !ok || !growing {
+		t.Fatalf("Unexpected ok=%v growing=%v", ok, growing)
+	}
+	if math.Abs(growth-1e6)/1e6 > 0.01 {
+		t.Errorf("Unexpected growth %v, expected about 1e6", growth)
+	}
+}
+
+func TestCapacityHistoryRecord(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.json")
+	h := &capacityHistory{Pools: make(map[string][]capacitySample)}
+	if err := h.load(path); err != nil {
+		t.Fatalf("Unexpected error loading missing history: %v", err)
+	}
+	lookback := 10 * time.Hour
+	for _, s := range linearSeries(24, 1000, 10, 2000000) {
+		if _, err := h.record("test/pool", s, lookback); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+	// Samples closer than lookback/capacityHistorySamples are not recorded, but
+	// still move the lookback window forward
+	samples, err := h.record("test/pool", capacitySample{Time: 1700000000 + 23*3600 + 1, Used: 1, Capacity: 1}, lookback)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(samples) != 10 {
+		t.Errorf("Unexpected sample count %d, expected 10", len(samples))
+	}
+	if samples[0].Time != 1700000000+14*3600 {
+		t.Errorf("Unexpected oldest sample time %d", samples[0].Time)
+	}
+
+	reloaded := &capacityHistory{Pools: make(map[string][]capacitySample)}
+	if err := reloaded.load(path); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(reloaded.Pools["test/pool"]) != 10 {
+		t.Errorf("Unexpected reloaded sample count %d, expected 10", len(reloaded.Pools["test/pool"]))
+	}
+}
+
+func TestCapacityForecastCollector(t *testing.T) {
+	timeNow = func() time.Time { return time.Unix(1700086400, 0) }
+	defer func() { timeNow = time.Now }()
+	poolHistory = &capacityHistory{Pools: map[string][]capacitySample{
+		// Pool_2 used 3e13 of 5e13 bytes now and grew by 1e12 bytes per day
+		"test/040000006D039EA000CF32BB000000D868E4C6E3": {
+			{Time: 1700000000, Used: 2.9e13, Capacity: 5e13},
+		},
+		// A deleted pool of the target and a pool of another target
+		"test/040000006D039EA000CF32BB000000D868E4C6E9": {
+			{Time: 1700000000, Used: 1e12, Capacity: 5e13},
+		},
+		"other/040000006D039EA000CF32BB000000D868E4C6E9": {
+			{Time: 1700000000, Used: 1e12, Capacity: 5e13},
+		},
+	}}
+	defer func() { poolHistory = &capacityHistory{Pools: make(map[string][]capacitySample)} }()
+	expected := `# HELP eseries_pool_forecast_window_seconds Time span of the usage history the storage pool forecast is based on
+# TYPE eseries_pool_forecast_window_seconds gauge
+eseries_pool_forecast_window_seconds{pool="Pool_2"} 86400
+# HELP eseries_pool_time_until_full_seconds Estimated time until the storage pool is full at the current growth rate, absent when the pool is not growing
+# TYPE eseries_pool_time_until_full_seconds gauge
+eseries_pool_time_until_full_seconds{pool="Pool_2"} 1.728e+06
+# HELP eseries_exporter_collect_error Indicates if error has occurred during collection
+# TYPE eseries_exporter_collect_error gauge
+eseries_exporter_collect_error{collector="capacity-forecast"} 0
+`
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if !strings.HasSuffix(req.URL.Path, "/storage-pools") {
+			http.Error(rw, "not found", http.StatusNotFound)
+			return
+		}
+		data, _ := os.ReadFile("testdata/storage-pools-response.json")
+		_, _ = rw.Write(data)
+	}))
+	defer server.Close()
+	baseURL, _ := url.Parse(server.URL)
+	target := config.Target{
+		Name:                     "test",
+		User:                     "test",
+		Password:                 "test",
+		BaseURL:                  baseURL,
+		HttpClient:               &http.Client{},
+		CapacityForecastLookback: config.DefaultCapacityForecastLookback,
+	}
+	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+	collector := NewCapacityForecastExporter(target, logger)
+	gatherers := setupGatherer(collector)
+	// Pool_1 has a single sample and no forecast yet
+	if val, err := testutil.GatherAndCount(gatherers); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	} else if val != 5 {
+		t.Errorf("Unexpected collection count %d, expected 5", val)
+	}
+	if err := testutil.GatherAndCompare(gatherers, strings.NewReader(expected),
+		"eseries_pool_forecast_window_seconds", "eseries_pool_time_until_full_seconds", "eseries_exporter_collect_error"); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+	history := poolHistory.Pools["test/040000006D039EA000CF32BB000000D868E4C6E3"]
+	if len(history) != 2 {
+		t.Errorf("Unexpected Pool_2 history length %d, expected 2", len(history))
+	}
+	if _, ok := poolHistory.Pools["test/040000006D039EA000CF32BB000000D868E4C6E9"]; ok {
+		t.Errorf("Expected the history of the deleted pool to be pruned")
+	}
+	if _, ok := poolHistory.Pools["other/040000006D039EA000CF32BB000000D868E4C6E9"]; !ok {
+		t.Errorf("Expected the history of another target to be kept")
+	}
+}
+
+func TestCapacityForecastCollectorError(t *testing.T) {
+	poolHistory = &capacityHistory{Pools: map[string][]capacitySample{
+		"test/040000006D039EA000CF32BB000000D868E4C6E3": {
+			{Time: 1700000000, Used: 2.9e13, Capacity: 5e13},
+		},
+	}}
+	defer func() { poolHistory = &capacityHistory{Pools: make(map[string][]capacitySample)} }()
+	expected := `# HELP eseries_exporter_collect_error Indicates if error has occurred during collection
+# TYPE eseries_exporter_collect_error gauge
+eseries_exporter_collect_error{collector="capacity-forecast"} 1
+`
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		http.Error(rw, "error", http.StatusNotFound)
+	}))
+	defer server.Close()
+	baseURL, _ := url.Parse(server.URL)
+	target := config.Target{
+		Name:       "test",
+		User:       "test",
+		Password:   "test",
+		BaseURL:    baseURL,
+		HttpClient: &http.Client{},
+	}
+	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+	collector := NewCapacityForecastExporter(target, logger)
+	gatherers := setupGatherer(collector)
+	if val, err := testutil.GatherAndCount(gatherers); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	} else if val != 2 {
+		t.Errorf("Unexpected collection count %d, expected 2", val)
+	}
+	if err := testutil.GatherAndCompare(gatherers, strings.NewReader(expected),
+		"eseries_pool_time_until_full_seconds", "eseries_exporter_collect_error"); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+	// A failed scrape does not tell which pools were deleted
+	if len(poolHistory.Pools) != 1 {
+		t.Errorf("Unexpected history pools %d after a failed scrape, expected 1", len(poolHistory.Pools))
+	}
+}
@@ -1,12 +1,17 @@
 package collector
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 
@@ -18,6 +23,8 @@ const (
 )
 
 var (
+	// timeNow is the clock used for ages derived from array timestamps, replaced in tests.
+	timeNow         = time.Now
 	collectorState  = make(map[string]bool)
 	factories       = make(map[string]func(target config.Target, logger *slog.Logger) Collector)
 	collectDuration = prometheus.NewDesc(
@@ -30,6 +37,33 @@ var (
 		[]string{"collector"}, nil)
 )
 
+// responseError is returned for non-200 responses, its message is the response body.
+type responseError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *responseError) Error() string {
+	return e.Body
+}
+
+// isNotFound reports whether err is a 404 response, which some endpoints return
+// when the feature they describe is not configured.
+func isNotFound(err error) bool {
+	var respErr *responseError
+	return errors.As(err, &respErr) && respErr.StatusCode == http.StatusNotFound
+}
+
+// parseTimeMS parses a millisecond Unix timestamp such as observedTimeInMS or
+// lastResetTimeInMS, returning the zero time when the array did not report one.
+func parseTimeMS(ms string) time.Time {
+	value, err := strconv.ParseInt(ms, 10, 64)
+	if err != nil || value <= 0 {
+		return time.Time{}
+	}
+	return time.UnixMilli(value)
+}
+
 type Collector interface {
 	Describe(ch chan<- *prometheus.Desc)
 	Collect(ch chan<- prometheus.Metric)
@@ -73,6 +107,97 @@ func sliceContains(slice []string, str string) bool {
 }
 
 func getRequest(target config.Target, path string, logger *slog.Logger) ([]byte, error) {
+	return doRequest(target, http.MethodGet, path, false, logger)
+}
+
+// getOptionalRequest performs a GET request on an endpoint that firmware or arrays
+// without the feature answer with 404, which is then only logged at debug level.
+func getOptionalRequest(target config.Target, path string, logger *slog.Logger) ([]byte, error) {
+	return doRequest(target, http.MethodGet, path, true, logger)
+}
+
+// maxParallelRequests bounds the requests getResponses sends to the proxy at once.
+const maxParallelRequests = 4
+
+// request is a path fetched by getResponses. Optional paths belong to features that
+// may not be configured or licensed, a 404 on them yields an empty response.
+type request struct {
+	path     string
+	optional bool
+}
+
+// response is the outcome of one request of getResponses.
+type response struct {
+	path string
+	body []byte
+	err  error
+}
+
+// decode unmarshals the response body into v. It returns the request error if the
+// request failed and leaves v untouched for an optional path that was not found.
+func (r response) decode(v any) error {
+	if r.err != nil {
+		return r.err
+	}
+	if r.body == nil {
+		return nil
+	}
+	if err := json.Unmarshal(r.body, v); err != nil {
+		return fmt.Errorf("failed to unmarshal %s: %w", r.path, err)
+	}
+	return nil
+}
+
+// getRequests performs GET requests for all paths, at most maxParallelRequests at a
+// time, and returns the bodies in the same order as paths. Any failed request fails
+// the call, use getResponses where partial results are useful.
+func getRequests(target config.Target, paths []string, logger *slog.Logger) ([][]byte, error) {
+	requests := make([]request, len(paths))
+	for i, path := range paths {
+		requests[i] = request{path: path}
+	}
+	responses := getResponses(target, requests, logger)
+	bodies := make([][]byte, len(responses))
+	for i, r := range responses {
+		if r.err != nil {
+			return nil, r.err
+		}
+		bodies[i] = r.body
+	}
+	return bodies, nil
+}
+
+// getResponses performs GET requests for all requests, at most maxParallelRequests
+// at a time, and returns the responses in the same order. A failed request does not
+// affect the others, so collectors can export what was collected.
+func getResponses(target config.Target, requests []request, logger *slog.Logger) []response {
+	responses := make([]response, len(requests))
+	sem := make(chan struct{}, maxParallelRequests)
+	wg := &sync.WaitGroup{}
+	wg.Add(len(requests))
+	for i, r := range requests {
+		go func(i int, r request) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			body, err := doRequest(target, http.MethodGet, r.path, r.optional, logger)
+			if r.optional && isNotFound(err) {
+				body, err = nil, nil
+			}
+			responses[i] = response{path: r.path, body: body, err: err}
+		}(i, r)
+	}
+	wg.Wait()
+	return responses
+}
+
+// postRequest invokes a SYMbol procedure, which the proxy exposes as POST only.
+// Not every firmware provides every procedure, so a 404 is only logged at debug level.
+func postRequest(target config.Target, path string, logger *slog.Logger) ([]byte, error) {
+	return doRequest(target, http.MethodPost, path, true, logger)
+}
+
+func doRequest(target config.Target, method string, path string, optional bool, logger *slog.Logger) ([]byte, error) {
 	rel := &url.URL{Path: path}
 	u := target.BaseURL.ResolveReference(rel)
 	// We handle potential unescaping errors implicitly via URL parsing
@@ -83,14 +208,14 @@ func getRequest(target config.Target, path string, logger *slog.Logger) ([]byte,
 		unescaped = u.String()
 	}
 
-	req, err := http.NewRequest("GET", unescaped, nil)
+	req, err := http.NewRequest(method, unescaped, nil)
 	if err != nil {
 		return nil, err
 	}
 	req.Header.Set("Accept", "application/json")
 	req.SetBasicAuth(target.User, target.Password)
 
-	logger.Debug("Performing GET request", "url", u.String())
+	logger.Debug("Performing request", "method", method, "url", u.String())
 
 	resp, err := target.HttpClient.Do(req)
 	if err != nil {
@@ -106,8 +231,12 @@ func getRequest(target config.Target, path string, logger *slog.Logger) ([]byte,
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		logger.Error("Response error", "code", resp.StatusCode, "body", string(body))
-		return nil, fmt.Errorf("%s", body)
+		if optional && resp.StatusCode == http.StatusNotFound {
+			logger.Debug("Response not found", "url", u.String())
+		} else {
+			logger.Error("Response error", "code", resp.StatusCode, "body", string(body))
+		}
+		return nil, &responseError{StatusCode: resp.StatusCode, Body: string(body)}
 	}
 	return body, nil
 }
@@ -23,7 +23,20 @@
 package collector
 
 import (
+	"bytes"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
 	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/sckyzo/eseries_exporter/internal/config"
 )
 
 func setupGatherer(collector Collector) prometheus.Gatherer {
@@ -32,3 +45,114 @@ func setupGatherer(collector Collector) prometheus.Gatherer {
 	gatherers := prometheus.Gatherers{registry}
 	return gatherers
 }
+
+func TestParseTimeMS(t *testing.T) {
+	if got := parseTimeMS("1604971911000"); !got.Equal(time.Unix(1604971911, 0)) {
+		t.Errorf("Unexpected time %v", got)
+	}
+	for _, value := range []string{"", "0", "invalid"} {
+		if got := parseTimeMS(value); !got.IsZero() {
+			t.Errorf("Unexpected time %v for %q, expected zero", got, value)
+		}
+	}
+}
+
+func TestOptionalRequestNotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		http.Error(rw, "not found", http.StatusNotFound)
+	}))
+	defer server.Close()
+	baseURL, _ := url.Parse(server.URL)
+	target := config.Target{Name: "test", User: "test", Password: "test", BaseURL: baseURL, HttpClient: &http.Client{}}
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&logs, nil))
+	if _, err := getOptionalRequest(target, "/devmgr/v2/storage-systems/test/flash-cache", logger); !isNotFound(err) {
+		t.Errorf("Unexpected error %v, expected not found", err)
+	}
+	if _, err := postRequest(target, "/devmgr/v2/storage-systems/test/symbol/getEnergyStarData", logger); !isNotFound(err) {
+		t.Errorf("Unexpected error %v, expected not found", err)
+	}
+	if strings.Contains(logs.String(), "level=ERROR") {
+		t.Errorf("Unexpected error logged for optional request:\n%s", logs.String())
+	}
+	if _, err := getRequest(target, "/devmgr/v2/storage-systems/test/drives", logger); !isNotFound(err) {
+		t.Errorf("Unexpected error %v, expected not found", err)
+	}
+	if !strings.Contains(logs.String(), "level=ERROR") {
+		t.Errorf("Expected error logged for required request")
+	}
+}
+
+func TestGetResponses(t *testing.T) {
+	var lock sync.Mutex
+	var inFlight, maxInFlight int
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		lock.Lock()
+		inFlight++
+		if inFlight > maxInFlight {
+			maxInFlight = inFlight
+		}
+		lock.Unlock()
+		time.Sleep(20 * time.Millisecond)
+		lock.Lock()
+		inFlight--
+		lock.Unlock()
+		switch req.URL.Path {
+		case "/missing":
+			http.Error(rw, "not found", http.StatusNotFound)
+		case "/failing":
+			http.Error(rw, "error", http.StatusInternalServerError)
+		default:
+			_, _ = rw.Write([]byte(`["ok"]`))
+		}
+	}))
+	defer server.Close()
+	baseURL, _ := url.Parse(server.URL)
+	target := config.Target{Name: "test", User: "test", Password: "test", BaseURL: baseURL, HttpClient: &http.Client{}}
+	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+	requests := []request{{path: "/missing", optional: true}, {path: "/failing", optional: true}, {path: "/missing"}}
+	for i := 0; i < 2*maxParallelRequests; i++ {
+		requests = append(requests, request{path: "/ok"})
+	}
+	responses := getResponses(target, requests, logger)
+	var values []string
+	if err := responses[0].decode(&values); err != nil || values != nil {
+		t.Errorf("Unexpected optional not found response %v %v", values, err)
+	}
+	if err := responses[1].decode(&values); err == nil {
+		t.Errorf("Expected error for failing optional request")
+	}
+	if err := responses[2].decode(&values); !isNotFound(err) {
+		t.Errorf("Unexpected error %v, expected not found", err)
+	}
+	if err := responses[3].decode(&values); err != nil || len(values) != 1 {
+		t.Errorf("Unexpected response %v %v", values, err)
+	}
+	if maxInFlight != maxParallelRequests {
+		t.Errorf("Unexpected concurrency %d, expected %d", maxInFlight, maxParallelRequests)
+	}
+}
+
+func TestGetRequests(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/failing" {
+			http.Error(rw, "error", http.StatusInternalServerError)
+			return
+		}
+		_, _ = rw.Write([]byte(req.URL.Path))
+	}))
+	defer server.Close()
+	baseURL, _ := url.Parse(server.URL)
+	target := config.Target{Name: "test", User: "test", Password: "test", BaseURL: baseURL, HttpClient: &http.Client{}}
+	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+	bodies, err := getRequests(target, []string{"/first", "/second"}, logger)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if string(bodies[0]) != "/first" || string(bodies[1]) != "/second" {
+		t.Errorf("Unexpected bodies %q", bodies)
+	}
+	if _, err := getRequests(target, []string{"/first", "/failing"}, logger); err == nil {
+		t.Errorf("Expected error for failing request")
+	}
+}
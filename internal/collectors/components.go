@@ -0,0 +1,191 @@
+package collector
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/sckyzo/eseries_exporter/internal/config"
+)
+
+var (
+	trayLabel = componentLabel{
+		Name: "tray",
+		Value: func(c InventoryComponent, trays map[string]string) string {
+			return trays[c.PhysicalLocation.TrayRef]
+		},
+	}
+	slotLabel = componentLabel{
+		Name: "slot",
+		Value: func(c InventoryComponent, trays map[string]string) string {
+			return strconv.Itoa(c.PhysicalLocation.Slot)
+		},
+	}
+
+	batteryComponent = StatusComponent{
+		Name: "battery",
+		Path: "batteries",
+		Help: "Status of battery hardware device",
+		Statuses: []string{"optimal", "fullCharging", "nearExpiration", "failed", "removed", "notInConfig",
+			"configMismatch", "learning", "overtemp", "expired", "maintenanceCharging", "replacementRequired"},
+		Labels: []componentLabel{trayLabel, slotLabel},
+	}
+	fanComponent = StatusComponent{
+		Name:     "fan",
+		Path:     "fans",
+		Help:     "Status of fan hardware device",
+		Statuses: []string{"optimal", "failed", "removed"},
+		Labels:   []componentLabel{trayLabel, slotLabel},
+	}
+	powerSupplyComponent = StatusComponent{
+		Name:     "power_supply",
+		Path:     "powerSupplies",
+		Help:     "Status of power supply hardware device",
+		Statuses: []string{"optimal", "failed", "removed", "noinput"},
+		Labels:   []componentLabel{trayLabel, slotLabel},
+	}
+	cacheMemoryDimmComponent = StatusComponent{
+		Name:     "cache_memory_dimm",
+		Path:     "cacheMemoryDimms",
+		Help:     "Status of cache memory DIMM hardware device",
+		Statuses: []string{"optimal", "empty", "failed"},
+		Labels:   []componentLabel{trayLabel, slotLabel},
+	}
+	thermalSensorComponent = StatusComponent{
+		Name:     "thermal_sensor",
+		Path:     "thermalSensors",
+		Help:     "Status of thermal sensor hardware device",
+		Statuses: []string{"optimal", "nominalTempExceed", "maxTempExceed", "removed"},
+		Labels:   []componentLabel{trayLabel, slotLabel},
+	}
+	driveComponent = StatusComponent{
+		Name: "drive",
+		Path: "drives",
+		Help: "Drive status",
+		Statuses: []string{"optimal", "failed", "replaced", "bypassed", "unresponsive", "removed", "incompatible",
+			"dataRelocation", "preFailCopy", "preFailCopyPending", "__UNDEFINED"},
+		Labels: []componentLabel{trayLabel, slotLabel},
+	}
+
+	// hardwareInventoryComponents are the components exported by the hardware-inventory collector.
+	hardwareInventoryComponents = []StatusComponent{
+		batteryComponent,
+		fanComponent,
+		powerSupplyComponent,
+		cacheMemoryDimmComponent,
+		thermalSensorComponent,
+	}
+)
+
+// StatusComponent declares a hardware-inventory component whose status is exported
+// as one series per known status plus an "unknown" bucket.
+type StatusComponent struct {
+	// Name is the metric subsystem and the key used in a module's component_statuses,
+	// it must be listed in config.ComponentNames.
+	Name string
+	// Path is the key holding the component list in the hardware-inventory response.
+	Path     string
+	Help     string
+	Statuses []string
+	Labels   []componentLabel
+}
+
+type componentLabel struct {
+	Name  string
+	Value func(c InventoryComponent, trays map[string]string) string
+}
+
+// InventoryComponent holds the fields shared by every hardware-inventory component.
+type InventoryComponent struct {
+	ID               string           `json:"id"`
+	Status           string           `json:"status"`
+	PhysicalLocation PhysicalLocation `json:"physicalLocation"`
+}
+
+type PhysicalLocation struct {
+	Slot    int    `json:"slot"`
+	TrayRef string `json:"trayRef"`
+	Label   string `json:"label"`
+}
+
+type Tray struct {
+	TrayRef string `json:"trayRef"`
+	ID      int    `json:"trayId"`
+}
+
+// componentStatusMetric binds a StatusComponent to its descriptor and the statuses
+// known for a given target.
+type componentStatusMetric struct {
+	component StatusComponent
+	desc      *prometheus.Desc
+	statuses  []string
+}
+
+func newComponentStatusMetric(component StatusComponent, target config.Target) *componentStatusMetric {
+	labels := make([]string, 0, len(component.Labels)+1)
+	for _, l := range component.Labels {
+		labels = append(labels, l.Name)
+	}
+	labels = append(labels, "status")
+	statuses := append([]string{}, component.Statuses...)
+	for _, s := range target.ComponentStatuses[component.Name] {
+		if !sliceContains(statuses, s) {
+			statuses = append(statuses, s)
+		}
+	}
+	return &componentStatusMetric{
+		component: component,
+		desc: prometheus.NewDesc(prometheus.BuildFQName(namespace, component.Name, "status"),
+			component.Help, labels, nil),
+		statuses: statuses,
+	}
+}
+
+func (m *componentStatusMetric) labelValues(c InventoryComponent, trays map[string]string) []string {
+	values := make([]string, 0, len(m.component.Labels)+1)
+	for _, l := range m.component.Labels {
+		values = append(values, l.Value(c, trays))
+	}
+	return values
+}
+
+func (m *componentStatusMetric) collect(ch chan<- prometheus.Metric, c InventoryComponent, trays map[string]string) {
+	labelValues := m.labelValues(c, trays)
+	for _, s := range m.statuses {
+		var value float64
+		if strings.EqualFold(s, c.Status) {
+			value = 1
+		}
+		ch <- prometheus.MustNewConstMetric(m.desc, prometheus.GaugeValue, value, append(labelValues, s)...)
+	}
+	var unknown float64
+	if !sliceContains(m.statuses, c.Status) {
+		unknown = 1
+	}
+	ch <- prometheus.MustNewConstMetric(m.desc, prometheus.GaugeValue, unknown, append(labelValues, "unknown")...)
+}
+
+// decodeInventory unmarshals the list held under path in a hardware-inventory
+// response into v. A missing key leaves v untouched.
+func decodeInventory(inventory map[string]json.RawMessage, path string, v any) error {
+	raw, ok := inventory[path]
+	if !ok {
+		return nil
+	}
+	if err := json.Unmarshal(raw, v); err != nil {
+		return fmt.Errorf("failed to unmarshal %s: %w", path, err)
+	}
+	return nil
+}
+
+// trayIDs maps tray references to the tray ID used as the tray label.
+func trayIDs(trays []Tray) map[string]string {
+	ids := make(map[string]string)
+	for _, t := range trays {
+		ids[t.TrayRef] = strconv.Itoa(t.ID)
+	}
+	return ids
+}
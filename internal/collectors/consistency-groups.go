@@ -0,0 +1,209 @@
+package collector
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/sckyzo/eseries_exporter/internal/config"
+)
+
+type ConsistencyGroup struct {
+	ID                    string `json:"id"`
+	Label                 string `json:"label"`
+	CreationPendingStatus string `json:"creationPendingStatus"`
+}
+
+type ConsistencyGroupMember struct {
+	ConsistencyGroupID      string `json:"consistencyGroupId"`
+	VolumeID                string `json:"volumeId"`
+	PitGroupID              string `json:"pitGroupId"`
+	TotalRepositoryCapacity string `json:"totalRepositoryCapacity"`
+	UsedRepositoryCapacity  string `json:"usedRepositoryCapacity"`
+}
+
+type ConsistencyGroupSnapshot struct {
+	ID                string `json:"id"`
+	PitSequenceNumber string `json:"pitSequenceNumber"`
+	PitTimestamp      string `json:"pitTimestamp"`
+}
+
+// snapshotGroupStatusSeverity orders snapshot group statuses from healthy to
+// worst; statuses not listed rank just above optimal.
+var snapshotGroupStatusSeverity = map[string]int{
+	"optimal":       0,
+	"purging":       2,
+	"overThreshold": 3,
+	"degraded":      4,
+	"full":          5,
+	"failed":        6,
+}
+
+func statusSeverity(status string) int {
+	if severity, ok := snapshotGroupStatusSeverity[status]; ok {
+		return severity
+	}
+	return 1
+}
+
+// ConsistencyGroupDetails holds a consistency group with its member volumes and snapshots.
+type ConsistencyGroupDetails struct {
+	ConsistencyGroup
+	Members   []ConsistencyGroupMember
+	Snapshots []ConsistencyGroupSnapshot
+}
+
+type ConsistencyGroupsCollector struct {
+	Members               *prometheus.Desc
+	Status                *prometheus.Desc
+	Snapshots             *prometheus.Desc
+	NewestSnapshot        *prometheus.Desc
+	RepositoryCapacity    *prometheus.Desc
+	RepositoryUsed        *prometheus.Desc
+	RepositoryUtilization *prometheus.Desc
+	target                config.Target
+	logger                *slog.Logger
+}
+
+func init() {
+	registerCollector("consistency-groups", false, NewConsistencyGroupsExporter)
+}
+
+func NewConsistencyGroupsExporter(target config.Target, logger *slog.Logger) Collector {
+	labels := []string{"group"}
+	memberLabels := []string{"group", "volume"}
+	return &ConsistencyGroupsCollector{
+		Members: prometheus.NewDesc(prometheus.BuildFQName(namespace, "consistency_group", "members"),
+			"Number of member volumes in the consistency group", labels, nil),
+		Status: prometheus.NewDesc(prometheus.BuildFQName(namespace, "consistency_group", "status"),
+			"Status of the consistency group, the worst member snapshot group status or pending while the group is being created (1 for optimal, 0 otherwise)", append(labels, "status"), nil),
+		Snapshots: prometheus.NewDesc(prometheus.BuildFQName(namespace, "consistency_group", "snapshots"),
+			"Number of consistency group snapshots", labels, nil),
+		NewestSnapshot: prometheus.NewDesc(prometheus.BuildFQName(namespace, "consistency_group", "newest_snapshot_timestamp_seconds"),
+			"Creation time of the newest consistency group snapshot", labels, nil),
+		RepositoryCapacity: prometheus.NewDesc(prometheus.BuildFQName(namespace, "consistency_group", "member_repository_capacity_bytes"),
+			"Capacity of the member volume snapshot repository in bytes", memberLabels, nil),
+		RepositoryUsed: prometheus.NewDesc(prometheus.BuildFQName(namespace, "consistency_group", "member_repository_used_bytes"),
+			"Used capacity of the member volume snapshot repository in bytes", memberLabels, nil),
+		RepositoryUtilization: prometheus.NewDesc(prometheus.BuildFQName(namespace, "consistency_group", "member_repository_utilization_ratio"),
+			"Utilization ratio of the member volume snapshot repository (0-1)", memberLabels, nil),
+		target: target,
+		logger: logger,
+	}
+}
+
+func (c *ConsistencyGroupsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.Members
+	ch <- c.Status
+	ch <- c.Snapshots
+	ch <- c.NewestSnapshot
+	ch <- c.RepositoryCapacity
+	ch <- c.RepositoryUsed
+	ch <- c.RepositoryUtilization
+}
+
+func (c *ConsistencyGroupsCollector) Collect(ch chan<- prometheus.Metric) {
+	c.logger.Debug("Collecting consistency groups metrics")
+	collectTime := time.Now()
+	var errorMetric int
+	groups, snapshotGroups, volumes, err := c.collect()
+	if err != nil {
+		c.logger.Error("Collection failed", "error", err)
+		errorMetric = 1
+	}
+
+	labels := volumeLabels(volumes)
+	statuses := make(map[string]string)
+	for _, g := range snapshotGroups {
+		statuses[g.ID] = g.Status
+	}
+
+	for _, g := range groups {
+		status := "optimal"
+		if g.CreationPendingStatus != "" && g.CreationPendingStatus != "none" {
+			status = "pending"
+		}
+		for _, m := range g.Members {
+			if s, ok := statuses[m.PitGroupID]; ok && statusSeverity(s) > statusSeverity(status) {
+				status = s
+			}
+		}
+		statusValue := 0.0
+		if status == "optimal" {
+			statusValue = 1.0
+		}
+		ch <- prometheus.MustNewConstMetric(c.Members, prometheus.GaugeValue, float64(len(g.Members)), g.Label)
+		ch <- prometheus.MustNewConstMetric(c.Status, prometheus.GaugeValue, statusValue, g.Label, status)
+
+		// Each consistency group snapshot holds one image per member sharing a sequence number
+		sequences := make(map[string]bool)
+		var newest float64
+		for _, s := range g.Snapshots {
+			sequences[s.PitSequenceNumber] = true
+			timestamp, err := strconv.ParseFloat(s.PitTimestamp, 64)
+			if err == nil && timestamp > newest {
+				newest = timestamp
+			}
+		}
+		ch <- prometheus.MustNewConstMetric(c.Snapshots, prometheus.GaugeValue, float64(len(sequences)), g.Label)
+		if newest > 0 {
+			ch <- prometheus.MustNewConstMetric(c.NewestSnapshot, prometheus.GaugeValue, newest, g.Label)
+		}
+
+		for _, m := range g.Members {
+			volume := lookupLabel(labels, m.VolumeID)
+			capacityBytes, _ := strconv.ParseFloat(m.TotalRepositoryCapacity, 64)
+			usedBytes, _ := strconv.ParseFloat(m.UsedRepositoryCapacity, 64)
+			utilizationRatio := 0.0
+			if capacityBytes > 0 {
+				utilizationRatio = usedBytes / capacityBytes
+			}
+			ch <- prometheus.MustNewConstMetric(c.RepositoryCapacity, prometheus.GaugeValue, capacityBytes, g.Label, volume)
+			ch <- prometheus.MustNewConstMetric(c.RepositoryUsed, prometheus.GaugeValue, usedBytes, g.Label, volume)
+			ch <- prometheus.MustNewConstMetric(c.RepositoryUtilization, prometheus.GaugeValue, utilizationRatio, g.Label, volume)
+		}
+	}
+
+	ch <- prometheus.MustNewConstMetric(collectError, prometheus.GaugeValue, float64(errorMetric), "consistency-groups")
+	ch <- prometheus.MustNewConstMetric(collectDuration, prometheus.GaugeValue, time.Since(collectTime).Seconds(), "consistency-groups")
+}
+
+func (c *ConsistencyGroupsCollector) collect() ([]ConsistencyGroupDetails, []SnapshotGroup, []Volume, error) {
+	var groups []ConsistencyGroup
+	var snapshotGroups []SnapshotGroup
+	var volumes []Volume
+	responses := getResponses(c.target, []request{
+		{path: fmt.Sprintf("/devmgr/v2/storage-systems/%s/consistency-groups", c.target.Name), optional: true},
+		{path: fmt.Sprintf("/devmgr/v2/storage-systems/%s/snapshot-groups", c.target.Name), optional: true},
+		{path: fmt.Sprintf("/devmgr/v2/storage-systems/%s/volumes", c.target.Name)},
+	}, c.logger)
+	errs := []error{
+		responses[0].decode(&groups),
+		responses[1].decode(&snapshotGroups),
+		responses[2].decode(&volumes),
+	}
+
+	// Members and snapshots are only listed per consistency group
+	requests := make([]request, 0, 2*len(groups))
+	for _, g := range groups {
+		requests = append(requests,
+			request{path: fmt.Sprintf("/devmgr/v2/storage-systems/%s/consistency-groups/%s/member-volumes", c.target.Name, g.ID)},
+			request{path: fmt.Sprintf("/devmgr/v2/storage-systems/%s/consistency-groups/%s/snapshots", c.target.Name, g.ID)})
+	}
+	responses = getResponses(c.target, requests, c.logger)
+	details := make([]ConsistencyGroupDetails, 0, len(groups))
+	for i, g := range groups {
+		d := ConsistencyGroupDetails{ConsistencyGroup: g}
+		// A group whose details failed is skipped rather than reported empty
+		if err := errors.Join(responses[2*i].decode(&d.Members), responses[2*i+1].decode(&d.Snapshots)); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		details = append(details, d)
+	}
+	return details, snapshotGroups, volumes, errors.Join(errs...)
+}
@@ -0,0 +1,186 @@
+package collector
+
+import (
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/sckyzo/eseries_exporter/internal/config"
+)
+
+func TestConsistencyGroupsCollector(t *testing.T) {
+	fixtures := make(map[string][]byte)
+	for path, file := range map[string]string{
+		"consistency-groups": "testdata/consistency-groups.json",
+		"consistency-groups/2A00000060080E500043A2C4000005015E5B1A01/member-volumes": "testdata/consistency-group-members.json",
+		"consistency-groups/2A00000060080E500043A2C4000005015E5B1A01/snapshots":      "testdata/consistency-group-snapshots.json",
+		"snapshot-groups": "testdata/snapshot-groups.json",
+		"volumes":         "testdata/volumes-response.json",
+	} {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatalf("Error loading fixture data: %s", err.Error())
+		}
+		fixtures[path] = data
+	}
+	expected := `# HELP eseries_consistency_group_members Number of member volumes in the consistency group
+# TYPE eseries_consistency_group_members gauge
+eseries_consistency_group_members{group="CG_Oracle"} 2
+# HELP eseries_consistency_group_status Status of the consistency group, the worst member snapshot group status or pending while the group is being created (1 for optimal, 0 otherwise)
+# TYPE eseries_consistency_group_status gauge
+eseries_consistency_group_status{group="CG_Oracle",status="full"} 0
+# HELP eseries_consistency_group_snapshots Number of consistency group snapshots
+# TYPE eseries_consistency_group_snapshots gauge
+eseries_consistency_group_snapshots{group="CG_Oracle"} 2
+# HELP eseries_consistency_group_newest_snapshot_timestamp_seconds Creation time of the newest consistency group snapshot
+# TYPE eseries_consistency_group_newest_snapshot_timestamp_seconds gauge
+eseries_consistency_group_newest_snapshot_timestamp_seconds{group="CG_Oracle"} 1.7e+09
+# HELP eseries_consistency_group_member_repository_capacity_bytes Capacity of the member volume snapshot repository in bytes
+# TYPE eseries_consistency_group_member_repository_capacity_bytes gauge
+eseries_consistency_group_member_repository_capacity_bytes{group="CG_Oracle",volume="Volume_1"} 1.073741824e+10
+eseries_consistency_group_member_repository_capacity_bytes{group="CG_Oracle",volume="Volume_3"} 1.073741824e+10
+# HELP eseries_consistency_group_member_repository_used_bytes Used capacity of the member volume snapshot repository in bytes
+# TYPE eseries_consistency_group_member_repository_used_bytes gauge
+eseries_consistency_group_member_repository_used_bytes{group="CG_Oracle",volume="Volume_1"} 2.68435456e+09
+eseries_consistency_group_member_repository_used_bytes{group="CG_Oracle",volume="Volume_3"} 1.073741824e+10
+# HELP eseries_consistency_group_member_repository_utilization_ratio Utilization ratio of the member volume snapshot repository (0-1)
+# TYPE eseries_consistency_group_member_repository_utilization_ratio gauge
+eseries_consistency_group_member_repository_utilization_ratio{group="CG_Oracle",volume="Volume_1"} 0.25
+eseries_consistency_group_member_repository_utilization_ratio{group="CG_Oracle",volume="Volume_3"} 1
+# HELP eseries_exporter_collect_error Indicates if error has occurred during collection
+# TYPE eseries_exporter_collect_error gauge
+eseries_exporter_collect_error{collector="consistency-groups"} 0
+`
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		path := strings.TrimPrefix(req.URL.Path, "/devmgr/v2/storage-systems/test/")
+		data, ok := fixtures[path]
+		if !ok {
+			http.Error(rw, "not found", http.StatusNotFound)
+			return
+		}
+		_, _ = rw.Write(data)
+	}))
+	defer server.Close()
+	baseURL, _ := url.Parse(server.URL)
+	target := config.Target{
+		Name:       "test",
+		User:       "test",
+		Password:   "test",
+		BaseURL:    baseURL,
+		HttpClient: &http.Client{},
+	}
+	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+	collector := NewConsistencyGroupsExporter(target, logger)
+	gatherers := setupGatherer(collector)
+	if val, err := testutil.GatherAndCount(gatherers); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	} else if val != 12 {
+		t.Errorf("Unexpected collection count %d, expected 12", val)
+	}
+	if err := testutil.GatherAndCompare(gatherers, strings.NewReader(expected),
+		"eseries_consistency_group_members", "eseries_consistency_group_status", "eseries_consistency_group_snapshots",
+		"eseries_consistency_group_newest_snapshot_timestamp_seconds", "eseries_consistency_group_member_repository_capacity_bytes",
+		"eseries_consistency_group_member_repository_used_bytes", "eseries_consistency_group_member_repository_utilization_ratio",
+		"eseries_exporter_collect_error"); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+}
+
+func TestConsistencyGroupsCollectorError(t *testing.T) {
+	expected := `# HELP eseries_exporter_collect_error Indicates if error has occurred during collection
+# TYPE eseries_exporter_collect_error gauge
+eseries_exporter_collect_error{collector="consistency-groups"} 1
+`
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		http.Error(rw, "error", http.StatusNotFound)
+	}))
+	defer server.Close()
+	baseURL, _ := url.Parse(server.URL)
+	target := config.Target{
+		Name:       "test",
+		User:       "test",
+		Password:   "test",
+		BaseURL:    baseURL,
+		HttpClient: &http.Client{},
+	}
+	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+	collector := NewConsistencyGroupsExporter(target, logger)
+	gatherers := setupGatherer(collector)
+	if val, err := testutil.GatherAndCount(gatherers); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	} else if val != 2 {
+		t.Errorf("Unexpected collection count %d, expected 2", val)
+	}
+	if err := testutil.GatherAndCompare(gatherers, strings.NewReader(expected),
+		"eseries_consistency_group_members", "eseries_exporter_collect_error"); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+}
+
+func TestConsistencyGroupsCollectorWorstStatus(t *testing.T) {
+	tests := []struct {
+		name                  string
+		creationPendingStatus string
+		memberStatuses        [2]string
+		expectedStatus        string
+	}{
+		{name: "failed after degraded", creationPendingStatus: "none", memberStatuses: [2]string{"degraded", "failed"}, expectedStatus: "failed"},
+		{name: "degraded after optimal", creationPendingStatus: "none", memberStatuses: [2]string{"optimal", "degraded"}, expectedStatus: "degraded"},
+		{name: "creation pending", creationPendingStatus: "waiting", memberStatuses: [2]string{"optimal", "optimal"}, expectedStatus: "pending"},
+		{name: "member worse than pending", creationPendingStatus: "waiting", memberStatuses: [2]string{"optimal", "full"}, expectedStatus: "full"},
+	}
+	members, err := os.ReadFile("testdata/consistency-group-members.json")
+	if err != nil {
+		t.Fatalf("Error loading fixture data: %s", err.Error())
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fixtures := map[string]string{
+				"consistency-groups": `[{"id": "2A00000060080E500043A2C4000005015E5B1A01", "label": "CG_Oracle", "creationPendingStatus": "` + test.creationPendingStatus + `"}]`,
+				"consistency-groups/2A00000060080E500043A2C4000005015E5B1A01/member-volumes": string(members),
+				"consistency-groups/2A00000060080E500043A2C4000005015E5B1A01/snapshots":      `[]`,
+				"snapshot-groups": `[{"id": "3300000060080E500043A2C40000042A5E3A1B9F", "status": "` + test.memberStatuses[0] + `"},
+					{"id": "3300000060080E500043A2C40000042C5E3A1C11", "status": "` + test.memberStatuses[1] + `"}]`,
+				"volumes": `[]`,
+			}
+			expectedValue := "0"
+			if test.expectedStatus == "optimal" {
+				expectedValue = "1"
+			}
+			expected := `# HELP eseries_consistency_group_status Status of the consistency group, the worst member snapshot group status or pending while the group is being created (1 for optimal, 0 otherwise)
+# TYPE eseries_consistency_group_status gauge
+eseries_consistency_group_status{group="CG_Oracle",status="` + test.expectedStatus + `"} ` + expectedValue + `
+`
+			server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				path := strings.TrimPrefix(req.URL.Path, "/devmgr/v2/storage-systems/test/")
+				data, ok := fixtures[path]
+				if !ok {
+					http.Error(rw, "not found", http.StatusNotFound)
+					return
+				}
+				_, _ = rw.Write([]byte(data))
+			}))
+			defer server.Close()
+			baseURL, _ := url.Parse(server.URL)
+			target := config.Target{
+				Name:       "test",
+				User:       "test",
+				Password:   "test",
+				BaseURL:    baseURL,
+				HttpClient: &http.Client{},
+			}
+			logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+			collector := NewConsistencyGroupsExporter(target, logger)
+			gatherers := setupGatherer(collector)
+			if err := testutil.GatherAndCompare(gatherers, strings.NewReader(expected), "eseries_consistency_group_status"); err != nil {
+				t.Errorf("unexpected collecting result:\n%s", err)
+			}
+		})
+	}
+}
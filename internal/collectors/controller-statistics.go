@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"log/slog"
+	"strconv"
 	"sync"
 	"time"
 
@@ -13,40 +14,80 @@ import (
 )
 
 type AnalysedControllerStatistics struct {
-	ID                   string `json:"controllerId"`
-	Label                string
-	AverageReadOpSize    float64 `json:"averageReadOpSize"`
-	AverageWriteOpSize   float64 `json:"averageWriteOpSize"`
-	CombinedResponseTime float64 `json:"combinedResponseTime"`
-	ReadResponseTime     float64 `json:"readResponseTime"`
-	WriteResponseTime    float64 `json:"writeResponseTime"`
-	MaxCpuUtilization    float64 `json:"maxCpuUtilization"`
-	CpuAvgUtilization    float64 `json:"cpuAvgUtilization"`
+	ID                            string `json:"controllerId"`
+	Label                         string
+	SourceController              string    `json:"sourceController"`
+	ObservedTimeInMS              string    `json:"observedTimeInMS"`
+	AverageReadOpSize             float64   `json:"averageReadOpSize"`
+	AverageWriteOpSize            float64   `json:"averageWriteOpSize"`
+	ReadIOps                      float64   `json:"readIOps"`
+	WriteIOps                     float64   `json:"writeIOps"`
+	OtherIOps                     float64   `json:"otherIOps"`
+	CombinedIOps                  float64   `json:"combinedIOps"`
+	ReadOps                       float64   `json:"readOps"`
+	WriteOps                      float64   `json:"writeOps"`
+	ReadPhysicalIOps              float64   `json:"readPhysicalIOps"`
+	WritePhysicalIOps             float64   `json:"writePhysicalIOps"`
+	ReadThroughput                float64   `json:"readThroughput"`
+	WriteThroughput               float64   `json:"writeThroughput"`
+	CombinedThroughput            float64   `json:"combinedThroughput"`
+	ReadResponseTime              float64   `json:"readResponseTime"`
+	ReadResponseTimeStdDev        float64   `json:"readResponseTimeStdDev"`
+	WriteResponseTime             float64   `json:"writeResponseTime"`
+	WriteResponseTimeStdDev       float64   `json:"writeResponseTimeStdDev"`
+	CombinedResponseTime          float64   `json:"combinedResponseTime"`
+	CombinedResponseTimeStdDev    float64   `json:"combinedResponseTimeStdDev"`
+	ReadHitResponseTime           float64   `json:"readHitResponseTime"`
+	ReadHitResponseTimeStdDev     float64   `json:"readHitResponseTimeStdDev"`
+	WriteHitResponseTime          float64   `json:"writeHitResponseTime"`
+	WriteHitResponseTimeStdDev    float64   `json:"writeHitResponseTimeStdDev"`
+	CombinedHitResponseTime       float64   `json:"combinedHitResponseTime"`
+	CombinedHitResponseTimeStdDev float64   `json:"combinedHitResponseTimeStdDev"`
+	CacheHitBytesPercent          float64   `json:"cacheHitBytesPercent"`
+	RandomIosPercent              float64   `json:"randomIosPercent"`
+	MirrorBytesPercent            float64   `json:"mirrorBytesPercent"`
+	FullStripeWritesBytesPercent  float64   `json:"fullStripeWritesBytesPercent"`
+	MaxCpuUtilization             float64   `json:"maxCpuUtilization"`
+	CpuAvgUtilization             float64   `json:"cpuAvgUtilization"`
+	Raid0BytesPercent             float64   `json:"raid0BytesPercent"`
+	Raid1BytesPercent             float64   `json:"raid1BytesPercent"`
+	Raid5BytesPercent             float64   `json:"raid5BytesPercent"`
+	Raid6BytesPercent             float64   `json:"raid6BytesPercent"`
+	DdpBytesPercent               float64   `json:"ddpBytesPercent"`
+	MaxCpuUtilizationPerCore      []float64 `json:"maxCpuUtilizationPerCore"`
+	CpuAvgUtilizationPerCore      []float64 `json:"cpuAvgUtilizationPerCore"`
 }
 
 type ControllerStatistics struct {
 	ID                              string `json:"controllerId"`
 	Label                           string
-	TotalIopsServiced               float64 `json:"totalIopsServiced"`
-	TotalBytesServiced              float64 `json:"totalBytesServiced"`
-	CacheHitsIopsTotal              float64 `json:"cacheHitsIopsTotal"`
-	CacheHitsBytesTotal             float64 `json:"cacheHitsBytesTotal"`
-	RandomIosTotal                  float64 `json:"randomIosTotal"`
-	RandomBytesTotal                float64 `json:"randomBytesTotal"`
-	ReadIopsTotal                   float64 `json:"readIopsTotal"`
-	ReadBytesTotal                  float64 `json:"readBytesTotal"`
-	WriteIopsTotal                  float64 `json:"writeIopsTotal"`
-	WriteBytesTotal                 float64 `json:"writeBytesTotal"`
-	MirrorIopsTotal                 float64 `json:"mirrorIopsTotal"`
-	MirrorBytesTotal                float64 `json:"mirrorBytesTotal"`
-	FullStripeWritesBytes           float64 `json:"fullStripeWritesBytes"`
-	Raid0BytesTransferred           float64 `json:"raid0BytesTransferred"`
-	Raid1BytesTransferred           float64 `json:"raid1BytesTransferred"`
-	Raid5BytesTransferred           float64 `json:"raid5BytesTransferred"`
-	Raid6BytesTransferred           float64 `json:"raid6BytesTransferred"`
-	DdpBytesTransferred             float64 `json:"ddpBytesTransferred"`
-	MaxPossibleBpsUnderCurrentLoad  float64 `json:"maxPossibleBpsUnderCurrentLoad"`
-	MaxPossibleIopsUnderCurrentLoad float64 `json:"maxPossibleIopsUnderCurrentLoad"`
+	LastResetTimeInMS               string                     `json:"lastResetTimeInMS"`
+	TotalIopsServiced               float64                    `json:"totalIopsServiced"`
+	TotalBytesServiced              float64                    `json:"totalBytesServiced"`
+	CacheHitsIopsTotal              float64                    `json:"cacheHitsIopsTotal"`
+	CacheHitsBytesTotal             float64                    `json:"cacheHitsBytesTotal"`
+	RandomIosTotal                  float64                    `json:"randomIosTotal"`
+	RandomBytesTotal                float64                    `json:"randomBytesTotal"`
+	ReadIopsTotal                   float64                    `json:"readIopsTotal"`
+	ReadBytesTotal                  float64                    `json:"readBytesTotal"`
+	WriteIopsTotal                  float64                    `json:"writeIopsTotal"`
+	WriteBytesTotal                 float64                    `json:"writeBytesTotal"`
+	MirrorIopsTotal                 float64                    `json:"mirrorIopsTotal"`
+	MirrorBytesTotal                float64                    `json:"mirrorBytesTotal"`
+	FullStripeWritesBytes           float64                    `json:"fullStripeWritesBytes"`
+	Raid0BytesTransferred           float64                    `json:"raid0BytesTransferred"`
+	Raid1BytesTransferred           float64                    `json:"raid1BytesTransferred"`
+	Raid5BytesTransferred           float64                    `json:"raid5BytesTransferred"`
+	Raid6BytesTransferred           float64                    `json:"raid6BytesTransferred"`
+	DdpBytesTransferred             float64                    `json:"ddpBytesTransferred"`
+	MaxPossibleBpsUnderCurrentLoad  float64                    `json:"maxPossibleBpsUnderCurrentLoad"`
+	MaxPossibleIopsUnderCurrentLoad float64                    `json:"maxPossibleIopsUnderCurrentLoad"`
+	CpuUtilizationStats             []ControllerCpuUtilization `json:"cpuUtilizationStats"`
+}
+
+// ControllerCpuUtilization holds the peak utilization of one CPU core since the last statistics reset
+type ControllerCpuUtilization struct {
+	MaxCpuUtilization float64 `json:"maxCpuUtilization"`
 }
 
 type ControllersInventory struct {
@@ -66,11 +107,41 @@ type ControllerPhysicalLocation struct {
 type ControllerStatisticsCollector struct {
 	AverageReadOpSize               *prometheus.Desc
 	AverageWriteOpSize              *prometheus.Desc
-	CombinedResponseTime            *prometheus.Desc
+	ReadIOps                        *prometheus.Desc
+	WriteIOps                       *prometheus.Desc
+	OtherIOps                       *prometheus.Desc
+	CombinedIOps                    *prometheus.Desc
+	ReadOps                         *prometheus.Desc
+	WriteOps                        *prometheus.Desc
+	ReadPhysicalIOps                *prometheus.Desc
+	WritePhysicalIOps               *prometheus.Desc
+	ReadThroughput                  *prometheus.Desc
+	WriteThroughput                 *prometheus.Desc
+	CombinedThroughput              *prometheus.Desc
 	ReadResponseTime                *prometheus.Desc
+	ReadResponseTimeStdDev          *prometheus.Desc
 	WriteResponseTime               *prometheus.Desc
+	WriteResponseTimeStdDev         *prometheus.Desc
+	CombinedResponseTime            *prometheus.Desc
+	CombinedResponseTimeStdDev      *prometheus.Desc
+	ReadHitResponseTime             *prometheus.Desc
+	ReadHitResponseTimeStdDev       *prometheus.Desc
+	WriteHitResponseTime            *prometheus.Desc
+	WriteHitResponseTimeStdDev      *prometheus.Desc
+	CombinedHitResponseTime         *prometheus.Desc
+	CombinedHitResponseTimeStdDev   *prometheus.Desc
+	CacheHitBytesPercent            *prometheus.Desc
+	RandomIosPercent                *prometheus.Desc
+	MirrorBytesPercent              *prometheus.Desc
+	FullStripeWritesBytesPercent    *prometheus.Desc
 	MaxCpuUtilization               *prometheus.Desc
 	CpuAvgUtilization               *prometheus.Desc
+	RaidBytesPercent                *prometheus.Desc
+	CoreCpuAvgUtilization           *prometheus.Desc
+	CoreMaxCpuUtilization           *prometheus.Desc
+	CorePeakCpuUtilization          *prometheus.Desc
+	LastResetTime                   *prometheus.Desc
+	StatisticsResets                *prometheus.Desc
 	TotalIopsServiced               *prometheus.Desc
 	TotalBytesServiced              *prometheus.Desc
 	CacheHitsIopsTotal              *prometheus.Desc
@@ -101,21 +172,82 @@ func init() {
 
 func NewControllerStatisticsExporter(target config.Target, logger *slog.Logger) Collector {
 	labels := []string{"controller", "controller_label"}
+	analysedLabels := []string{"controller", "controller_label", "source_controller"}
 	return &ControllerStatisticsCollector{
 		AverageReadOpSize: prometheus.NewDesc(prometheus.BuildFQName(namespace, "controller", "average_read_op_size_bytes"),
-			"Controller statistic averageReadOpSize", labels, nil),
+			"Controller statistic averageReadOpSize", analysedLabels, nil),
 		AverageWriteOpSize: prometheus.NewDesc(prometheus.BuildFQName(namespace, "controller", "average_write_op_size_bytes"),
-			"Controller statistic averageWriteOpSize", labels, nil),
-		CombinedResponseTime: prometheus.NewDesc(prometheus.BuildFQName(namespace, "controller", "combined_response_time_seconds"),
-			"Controller statistic combinedResponseTime", labels, nil),
+			"Controller statistic averageWriteOpSize", analysedLabels, nil),
+		ReadIOps: prometheus.NewDesc(prometheus.BuildFQName(namespace, "controller", "read_iops"),
+			"Controller statistic readIOps", analysedLabels, nil),
+		WriteIOps: prometheus.NewDesc(prometheus.BuildFQName(namespace, "controller", "write_iops"),
+			"Controller statistic writeIOps", analysedLabels, nil),
+		OtherIOps: prometheus.NewDesc(prometheus.BuildFQName(namespace, "controller", "other_iops"),
+			"Controller statistic otherIOps", analysedLabels, nil),
+		CombinedIOps: prometheus.NewDesc(prometheus.BuildFQName(namespace, "controller", "combined_iops"),
+			"Controller statistic combinedIOps", analysedLabels, nil),
+		ReadOps: prometheus.NewDesc(prometheus.BuildFQName(namespace, "controller", "read_ops"),
+			"Controller statistic readOps", analysedLabels, nil),
+		WriteOps: prometheus.NewDesc(prometheus.BuildFQName(namespace, "controller", "write_ops"),
+			"Controller statistic writeOps", analysedLabels, nil),
+		ReadPhysicalIOps: prometheus.NewDesc(prometheus.BuildFQName(namespace, "controller", "read_physical_iops"),
+			"Controller statistic readPhysicalIOps", analysedLabels, nil),
+		WritePhysicalIOps: prometheus.NewDesc(prometheus.BuildFQName(namespace, "controller", "write_physical_iops"),
+			"Controller statistic writePhysicalIOps", analysedLabels, nil),
+		ReadThroughput: prometheus.NewDesc(prometheus.BuildFQName(namespace, "controller", "read_throughput_bytes_per_second"),
+			"Controller statistic readThroughput", analysedLabels, nil),
+		WriteThroughput: prometheus.NewDesc(prometheus.BuildFQName(namespace, "controller", "write_throughput_bytes_per_second"),
+			"Controller statistic writeThroughput", analysedLabels, nil),
+		CombinedThroughput: prometheus.NewDesc(prometheus.BuildFQName(namespace, "controller", "combined_throughput_bytes_per_second"),
+			"Controller statistic combinedThroughput", analysedLabels, nil),
 		ReadResponseTime: prometheus.NewDesc(prometheus.BuildFQName(namespace, "controller", "read_response_time_seconds"),
-			"Controller statistic readResponseTime", labels, nil),
+			"Controller statistic readResponseTime", analysedLabels, nil),
+		ReadResponseTimeStdDev: prometheus.NewDesc(prometheus.BuildFQName(namespace, "controller", "read_response_time_stddev_seconds"),
+			"Controller statistic readResponseTimeStdDev", analysedLabels, nil),
 		WriteResponseTime: prometheus.NewDesc(prometheus.BuildFQName(namespace, "controller", "write_response_time_seconds"),
-			"Controller statistic writeResponseTime", labels, nil),
+			"Controller statistic writeResponseTime", analysedLabels, nil),
+		WriteResponseTimeStdDev: prometheus.NewDesc(prometheus.BuildFQName(namespace, "controller", "write_response_time_stddev_seconds"),
+			"Controller statistic writeResponseTimeStdDev", analysedLabels, nil),
+		CombinedResponseTime: prometheus.NewDesc(prometheus.BuildFQName(namespace, "controller", "combined_response_time_seconds"),
+			"Controller statistic combinedResponseTime", analysedLabels, nil),
+		CombinedResponseTimeStdDev: prometheus.NewDesc(prometheus.BuildFQName(namespace, "controller", "combined_response_time_stddev_seconds"),
+			"Controller statistic combinedResponseTimeStdDev", analysedLabels, nil),
+		ReadHitResponseTime: prometheus.NewDesc(prometheus.BuildFQName(namespace, "controller", "read_hit_response_time_seconds"),
+			"Controller statistic readHitResponseTime", analysedLabels, nil),
+		ReadHitResponseTimeStdDev: prometheus.NewDesc(prometheus.BuildFQName(namespace, "controller", "read_hit_response_time_stddev_seconds"),
+			"Controller statistic readHitResponseTimeStdDev", analysedLabels, nil),
+		WriteHitResponseTime: prometheus.NewDesc(prometheus.BuildFQName(namespace, "controller", "write_hit_response_time_seconds"),
+			"Controller statistic writeHitResponseTime", analysedLabels, nil),
+		WriteHitResponseTimeStdDev: prometheus.NewDesc(prometheus.BuildFQName(namespace, "controller", "write_hit_response_time_stddev_seconds"),
+			"Controller statistic writeHitResponseTimeStdDev", analysedLabels, nil),
+		CombinedHitResponseTime: prometheus.NewDesc(prometheus.BuildFQName(namespace, "controller", "combined_hit_response_time_seconds"),
+			"Controller statistic combinedHitResponseTime", analysedLabels, nil),
+		CombinedHitResponseTimeStdDev: prometheus.NewDesc(prometheus.BuildFQName(namespace, "controller", "combined_hit_response_time_stddev_seconds"),
+			"Controller statistic combinedHitResponseTimeStdDev", analysedLabels, nil),
+		CacheHitBytesPercent: prometheus.NewDesc(prometheus.BuildFQName(namespace, "controller", "cache_hit_bytes_ratio"),
+			"Controller statistic cacheHitBytesPercent (0.0-1.0 ratio of bytes served from cache)", analysedLabels, nil),
+		RandomIosPercent: prometheus.NewDesc(prometheus.BuildFQName(namespace, "controller", "random_ios_ratio"),
+			"Controller statistic randomIosPercent (0.0-1.0 ratio of random I/O)", analysedLabels, nil),
+		MirrorBytesPercent: prometheus.NewDesc(prometheus.BuildFQName(namespace, "controller", "mirror_bytes_ratio"),
+			"Controller statistic mirrorBytesPercent (0.0-1.0 ratio of bytes mirrored to the partner controller)", analysedLabels, nil),
+		FullStripeWritesBytesPercent: prometheus.NewDesc(prometheus.BuildFQName(namespace, "controller", "full_stripe_writes_bytes_ratio"),
+			"Controller statistic fullStripeWritesBytesPercent (0.0-1.0 ratio of bytes written as full stripes)", analysedLabels, nil),
 		MaxCpuUtilization: prometheus.NewDesc(prometheus.BuildFQName(namespace, "controller", "cpu_max_utilization_ratio"),
-			"Controller statistic maxCpuUtilization (0.0-1.0 ratio of CPU percent utilization)", labels, nil),
+			"Controller statistic maxCpuUtilization (0.0-1.0 ratio of CPU percent utilization)", analysedLabels, nil),
 		CpuAvgUtilization: prometheus.NewDesc(prometheus.BuildFQName(namespace, "controller", "cpu_average_utilization_ratio"),
-			"Controller statistic cpuAvgUtilization (0.0-1.0 ratio of CPU percent utilization)", labels, nil),
+			"Controller statistic cpuAvgUtilization (0.0-1.0 ratio of CPU percent utilization)", analysedLabels, nil),
+		RaidBytesPercent: prometheus.NewDesc(prometheus.BuildFQName(namespace, "controller", "raid_bytes_ratio"),
+			"Controller statistic raid0/1/5/6 and ddp BytesPercent (0.0-1.0 ratio of bytes transferred per RAID level)", append(analysedLabels, "raid_level"), nil),
+		CoreCpuAvgUtilization: prometheus.NewDesc(prometheus.BuildFQName(namespace, "controller", "cpu_core_average_utilization_ratio"),
+			"Controller statistic cpuAvgUtilizationPerCore (0.0-1.0 ratio of CPU percent utilization)", append(analysedLabels, "core"), nil),
+		CoreMaxCpuUtilization: prometheus.NewDesc(prometheus.BuildFQName(namespace, "controller", "cpu_core_max_utilization_ratio"),
+			"Controller statistic maxCpuUtilizationPerCore (0.0-1.0 ratio of CPU percent utilization)", append(analysedLabels, "core"), nil),
+		CorePeakCpuUtilization: prometheus.NewDesc(prometheus.BuildFQName(namespace, "controller", "cpu_core_peak_utilization_ratio"),
+			"Controller statistic cpuUtilizationStats maxCpuUtilization since the last statistics reset (0.0-1.0 ratio of CPU percent utilization)", append(labels, "core"), nil),
+		LastResetTime: prometheus.NewDesc(prometheus.BuildFQName(namespace, "controller", "last_reset_timestamp_seconds"),
+			"Controller statistic lastResetTime, the Unix time the controller counters were last reset", labels, nil),
+		StatisticsResets: prometheus.NewDesc(prometheus.BuildFQName(namespace, "controller", "statistics_resets_total"),
+			"Controller statistics resets observed by the exporter", labels, nil),
 		TotalIopsServiced: prometheus.NewDesc(prometheus.BuildFQName(namespace, "controller", "iops_total"),
 			"Controller statistic totalIopsServiced", labels, nil),
 		TotalBytesServiced: prometheus.NewDesc(prometheus.BuildFQName(namespace, "controller", "bytes_total"),
@@ -164,11 +296,41 @@ func NewControllerStatisticsExporter(target config.Target, logger *slog.Logger)
 func (c *ControllerStatisticsCollector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- c.AverageReadOpSize
 	ch <- c.AverageWriteOpSize
-	ch <- c.CombinedResponseTime
+	ch <- c.ReadIOps
+	ch <- c.WriteIOps
+	ch <- c.OtherIOps
+	ch <- c.CombinedIOps
+	ch <- c.ReadOps
+	ch <- c.WriteOps
+	ch <- c.ReadPhysicalIOps
+	ch <- c.WritePhysicalIOps
+	ch <- c.ReadThroughput
+	ch <- c.WriteThroughput
+	ch <- c.CombinedThroughput
 	ch <- c.ReadResponseTime
+	ch <- c.ReadResponseTimeStdDev
 	ch <- c.WriteResponseTime
+	ch <- c.WriteResponseTimeStdDev
+	ch <- c.CombinedResponseTime
+	ch <- c.CombinedResponseTimeStdDev
+	ch <- c.ReadHitResponseTime
+	ch <- c.ReadHitResponseTimeStdDev
+	ch <- c.WriteHitResponseTime
+	ch <- c.WriteHitResponseTimeStdDev
+	ch <- c.CombinedHitResponseTime
+	ch <- c.CombinedHitResponseTimeStdDev
+	ch <- c.CacheHitBytesPercent
+	ch <- c.RandomIosPercent
+	ch <- c.MirrorBytesPercent
+	ch <- c.FullStripeWritesBytesPercent
 	ch <- c.MaxCpuUtilization
 	ch <- c.CpuAvgUtilization
+	ch <- c.RaidBytesPercent
+	ch <- c.CoreCpuAvgUtilization
+	ch <- c.CoreMaxCpuUtilization
+	ch <- c.CorePeakCpuUtilization
+	ch <- c.LastResetTime
+	ch <- c.StatisticsResets
 	ch <- c.TotalIopsServiced
 	ch <- c.TotalBytesServiced
 	ch <- c.CacheHitsIopsTotal
@@ -201,37 +363,100 @@ func (c *ControllerStatisticsCollector) Collect(ch chan<- prometheus.Metric) {
 		errorMetric = 1
 	}
 
+	observations := newStatisticsObservations(c.target)
 	for _, s := range analyzedStatistics {
-		ch <- prometheus.MustNewConstMetric(c.AverageReadOpSize, prometheus.GaugeValue, s.AverageReadOpSize, s.ID, s.Label)
-		ch <- prometheus.MustNewConstMetric(c.AverageWriteOpSize, prometheus.GaugeValue, s.AverageWriteOpSize, s.ID, s.Label)
-		ch <- prometheus.MustNewConstMetric(c.CombinedResponseTime, prometheus.GaugeValue, s.CombinedResponseTime, s.ID, s.Label)
-		ch <- prometheus.MustNewConstMetric(c.ReadResponseTime, prometheus.GaugeValue, s.ReadResponseTime, s.ID, s.Label)
-		ch <- prometheus.MustNewConstMetric(c.WriteResponseTime, prometheus.GaugeValue, s.WriteResponseTime, s.ID, s.Label)
-		ch <- prometheus.MustNewConstMetric(c.MaxCpuUtilization, prometheus.GaugeValue, s.MaxCpuUtilization, s.ID, s.Label)
-		ch <- prometheus.MustNewConstMetric(c.CpuAvgUtilization, prometheus.GaugeValue, s.CpuAvgUtilization, s.ID, s.Label)
+		observed, fresh := observations.observe(s.ObservedTimeInMS)
+		if !fresh {
+			c.logger.Debug("Skipping stale controller statistics", "controller", s.ID, "observed", observed)
+			continue
+		}
+		ch <- observations.metric(observed, prometheus.MustNewConstMetric(c.AverageReadOpSize, prometheus.GaugeValue, s.AverageReadOpSize, s.ID, s.Label, s.SourceController))
+		ch <- observations.metric(observed, prometheus.MustNewConstMetric(c.AverageWriteOpSize, prometheus.GaugeValue, s.AverageWriteOpSize, s.ID, s.Label, s.SourceController))
+		ch <- observations.metric(observed, prometheus.MustNewConstMetric(c.ReadIOps, prometheus.GaugeValue, s.ReadIOps, s.ID, s.Label, s.SourceController))
+		ch <- observations.metric(observed, prometheus.MustNewConstMetric(c.WriteIOps, prometheus.GaugeValue, s.WriteIOps, s.ID, s.Label, s.SourceController))
+		ch <- observations.metric(observed, prometheus.MustNewConstMetric(c.OtherIOps, prometheus.GaugeValue, s.OtherIOps, s.ID, s.Label, s.SourceController))
+		ch <- observations.metric(observed, prometheus.MustNewConstMetric(c.CombinedIOps, prometheus.GaugeValue, s.CombinedIOps, s.ID, s.Label, s.SourceController))
+		ch <- observations.metric(observed, prometheus.MustNewConstMetric(c.ReadOps, prometheus.GaugeValue, s.ReadOps, s.ID, s.Label, s.SourceController))
+		ch <- observations.metric(observed, prometheus.MustNewConstMetric(c.WriteOps, prometheus.GaugeValue, s.WriteOps, s.ID, s.Label, s.SourceController))
+		ch <- observations.metric(observed, prometheus.MustNewConstMetric(c.ReadPhysicalIOps, prometheus.GaugeValue, s.ReadPhysicalIOps, s.ID, s.Label, s.SourceController))
+		ch <- observations.metric(observed, prometheus.MustNewConstMetric(c.WritePhysicalIOps, prometheus.GaugeValue, s.WritePhysicalIOps, s.ID, s.Label, s.SourceController))
+		ch <- observations.metric(observed, prometheus.MustNewConstMetric(c.ReadThroughput, prometheus.GaugeValue, s.ReadThroughput, s.ID, s.Label, s.SourceController))
+		ch <- observations.metric(observed, prometheus.MustNewConstMetric(c.WriteThroughput, prometheus.GaugeValue, s.WriteThroughput, s.ID, s.Label, s.SourceController))
+		ch <- observations.metric(observed, prometheus.MustNewConstMetric(c.CombinedThroughput, prometheus.GaugeValue, s.CombinedThroughput, s.ID, s.Label, s.SourceController))
+		ch <- observations.metric(observed, prometheus.MustNewConstMetric(c.ReadResponseTime, prometheus.GaugeValue, s.ReadResponseTime, s.ID, s.Label, s.SourceController))
+		ch <- observations.metric(observed, prometheus.MustNewConstMetric(c.ReadResponseTimeStdDev, prometheus.GaugeValue, s.ReadResponseTimeStdDev, s.ID, s.Label, s.SourceController))
+		ch <- observations.metric(observed, prometheus.MustNewConstMetric(c.WriteResponseTime, prometheus.GaugeValue, s.WriteResponseTime, s.ID, s.Label, s.SourceController))
+		ch <- observations.metric(observed, prometheus.MustNewConstMetric(c.WriteResponseTimeStdDev, prometheus.GaugeValue, s.WriteResponseTimeStdDev, s.ID, s.Label, s.SourceController))
+		ch <- observations.metric(observed, prometheus.MustNewConstMetric(c.CombinedResponseTime, prometheus.GaugeValue, s.CombinedResponseTime, s.ID, s.Label, s.SourceController))
+		ch <- observations.metric(observed, prometheus.MustNewConstMetric(c.CombinedResponseTimeStdDev, prometheus.GaugeValue, s.CombinedResponseTimeStdDev, s.ID, s.Label, s.SourceController))
+		ch <- observations.metric(observed, prometheus.MustNewConstMetric(c.ReadHitResponseTime, prometheus.GaugeValue, s.ReadHitResponseTime, s.ID, s.Label, s.SourceController))
+		ch <- observations.metric(observed, prometheus.MustNewConstMetric(c.ReadHitResponseTimeStdDev, prometheus.GaugeValue, s.ReadHitResponseTimeStdDev, s.ID, s.Label, s.SourceController))
+		ch <- observations.metric(observed, prometheus.MustNewConstMetric(c.WriteHitResponseTime, prometheus.GaugeValue, s.WriteHitResponseTime, s.ID, s.Label, s.SourceController))
+		ch <- observations.metric(observed, prometheus.MustNewConstMetric(c.WriteHitResponseTimeStdDev, prometheus.GaugeValue, s.WriteHitResponseTimeStdDev, s.ID, s.Label, s.SourceController))
+		ch <- observations.metric(observed, prometheus.MustNewConstMetric(c.CombinedHitResponseTime, prometheus.GaugeValue, s.CombinedHitResponseTime, s.ID, s.Label, s.SourceController))
+		ch <- observations.metric(observed, prometheus.MustNewConstMetric(c.CombinedHitResponseTimeStdDev, prometheus.GaugeValue, s.CombinedHitResponseTimeStdDev, s.ID, s.Label, s.SourceController))
+		ch <- observations.metric(observed, prometheus.MustNewConstMetric(c.CacheHitBytesPercent, prometheus.GaugeValue, s.CacheHitBytesPercent, s.ID, s.Label, s.SourceController))
+		ch <- observations.metric(observed, prometheus.MustNewConstMetric(c.RandomIosPercent, prometheus.GaugeValue, s.RandomIosPercent, s.ID, s.Label, s.SourceController))
+		ch <- observations.metric(observed, prometheus.MustNewConstMetric(c.MirrorBytesPercent, prometheus.GaugeValue, s.MirrorBytesPercent, s.ID, s.Label, s.SourceController))
+		ch <- observations.metric(observed, prometheus.MustNewConstMetric(c.FullStripeWritesBytesPercent, prometheus.GaugeValue, s.FullStripeWritesBytesPercent, s.ID, s.Label, s.SourceController))
+		ch <- observations.metric(observed, prometheus.MustNewConstMetric(c.MaxCpuUtilization, prometheus.GaugeValue, s.MaxCpuUtilization, s.ID, s.Label, s.SourceController))
+		ch <- observations.metric(observed, prometheus.MustNewConstMetric(c.CpuAvgUtilization, prometheus.GaugeValue, s.CpuAvgUtilization, s.ID, s.Label, s.SourceController))
+		ch <- observations.metric(observed, prometheus.MustNewConstMetric(c.RaidBytesPercent, prometheus.GaugeValue, s.Raid0BytesPercent, s.ID, s.Label, s.SourceController, "raid0"))
+		ch <- observations.metric(observed, prometheus.MustNewConstMetric(c.RaidBytesPercent, prometheus.GaugeValue, s.Raid1BytesPercent, s.ID, s.Label, s.SourceController, "raid1"))
+		ch <- observations.metric(observed, prometheus.MustNewConstMetric(c.RaidBytesPercent, prometheus.GaugeValue, s.Raid5BytesPercent, s.ID, s.Label, s.SourceController, "raid5"))
+		ch <- observations.metric(observed, prometheus.MustNewConstMetric(c.RaidBytesPercent, prometheus.GaugeValue, s.Raid6BytesPercent, s.ID, s.Label, s.SourceController, "raid6"))
+		ch <- observations.metric(observed, prometheus.MustNewConstMetric(c.RaidBytesPercent, prometheus.GaugeValue, s.DdpBytesPercent, s.ID, s.Label, s.SourceController, "raidDiskPool"))
+		for core, utilization := range s.CpuAvgUtilizationPerCore {
+			ch <- observations.metric(observed, prometheus.MustNewConstMetric(c.CoreCpuAvgUtilization, prometheus.GaugeValue, utilization, s.ID, s.Label, s.SourceController, strconv.Itoa(core)))
+		}
+		for core, utilization := range s.MaxCpuUtilizationPerCore {
+			ch <- observations.metric(observed, prometheus.MustNewConstMetric(c.CoreMaxCpuUtilization, prometheus.GaugeValue, utilization, s.ID, s.Label, s.SourceController, strconv.Itoa(core)))
+		}
 	}
+	observations.collect(ch, "controller-statistics")
 
+	seen := make(map[string]bool)
 	for _, s := range statistics {
-		ch <- prometheus.MustNewConstMetric(c.TotalIopsServiced, prometheus.CounterValue, s.TotalIopsServiced, s.ID, s.Label)
-		ch <- prometheus.MustNewConstMetric(c.TotalBytesServiced, prometheus.CounterValue, s.TotalBytesServiced, s.ID, s.Label)
-		ch <- prometheus.MustNewConstMetric(c.CacheHitsIopsTotal, prometheus.CounterValue, s.CacheHitsIopsTotal, s.ID, s.Label)
-		ch <- prometheus.MustNewConstMetric(c.CacheHitsBytesTotal, prometheus.CounterValue, s.CacheHitsBytesTotal, s.ID, s.Label)
-		ch <- prometheus.MustNewConstMetric(c.RandomIosTotal, prometheus.CounterValue, s.RandomIosTotal, s.ID, s.Label)
-		ch <- prometheus.MustNewConstMetric(c.RandomBytesTotal, prometheus.CounterValue, s.RandomBytesTotal, s.ID, s.Label)
-		ch <- prometheus.MustNewConstMetric(c.ReadIopsTotal, prometheus.CounterValue, s.ReadIopsTotal, s.ID, s.Label)
-		ch <- prometheus.MustNewConstMetric(c.ReadBytesTotal, prometheus.CounterValue, s.ReadBytesTotal, s.ID, s.Label)
-		ch <- prometheus.MustNewConstMetric(c.WriteIopsTotal, prometheus.CounterValue, s.WriteIopsTotal, s.ID, s.Label)
-		ch <- prometheus.MustNewConstMetric(c.WriteBytesTotal, prometheus.CounterValue, s.WriteBytesTotal, s.ID, s.Label)
-		ch <- prometheus.MustNewConstMetric(c.MirrorIopsTotal, prometheus.CounterValue, s.MirrorIopsTotal, s.ID, s.Label)
-		ch <- prometheus.MustNewConstMetric(c.MirrorBytesTotal, prometheus.CounterValue, s.MirrorBytesTotal, s.ID, s.Label)
-		ch <- prometheus.MustNewConstMetric(c.FullStripeWritesBytes, prometheus.CounterValue, s.FullStripeWritesBytes, s.ID, s.Label)
-		ch <- prometheus.MustNewConstMetric(c.Raid0BytesTransferred, prometheus.CounterValue, s.Raid0BytesTransferred, s.ID, s.Label)
-		ch <- prometheus.MustNewConstMetric(c.Raid1BytesTransferred, prometheus.CounterValue, s.Raid1BytesTransferred, s.ID, s.Label)
-		ch <- prometheus.MustNewConstMetric(c.Raid5BytesTransferred, prometheus.CounterValue, s.Raid5BytesTransferred, s.ID, s.Label)
-		ch <- prometheus.MustNewConstMetric(c.Raid6BytesTransferred, prometheus.CounterValue, s.Raid6BytesTransferred, s.ID, s.Label)
-		ch <- prometheus.MustNewConstMetric(c.DdpBytesTransferred, prometheus.CounterValue, s.DdpBytesTransferred, s.ID, s.Label)
-		ch <- prometheus.MustNewConstMetric(c.MaxPossibleBpsUnderCurrentLoad, prometheus.CounterValue, s.MaxPossibleBpsUnderCurrentLoad, s.ID, s.Label)
-		ch <- prometheus.MustNewConstMetric(c.MaxPossibleIopsUnderCurrentLoad, prometheus.CounterValue, s.MaxPossibleIopsUnderCurrentLoad, s.ID, s.Label)
+		created := parseTimeMS(s.LastResetTimeInMS)
+		if !created.IsZero() {
+			key := c.target.Name + "/controller/" + s.ID
+			seen[key] = true
+			resets := statisticsResets.observe(key, created)
+			ch <- prometheus.MustNewConstMetric(c.StatisticsResets, prometheus.CounterValue, resets, s.ID, s.Label)
+			// OpenMetrics scrapes and OTLP pushes carry the reset time as created instead
+			if !c.target.CreatedTimestamps {
+				ch <- prometheus.MustNewConstMetric(c.LastResetTime, prometheus.GaugeValue, float64(created.Unix()), s.ID, s.Label)
+			}
+		}
+		ch <- newCounter(c.target, c.TotalIopsServiced, s.TotalIopsServiced, created, s.ID, s.Label)
+		ch <- newCounter(c.target, c.TotalBytesServiced, s.TotalBytesServiced, created, s.ID, s.Label)
+		ch <- newCounter(c.target, c.CacheHitsIopsTotal, s.CacheHitsIopsTotal, created, s.ID, s.Label)
+		ch <- newCounter(c.target, c.CacheHitsBytesTotal, s.CacheHitsBytesTotal, created, s.ID, s.Label)
+		ch <- newCounter(c.target, c.RandomIosTotal, s.RandomIosTotal, created, s.ID, s.Label)
+		ch <- newCounter(c.target, c.RandomBytesTotal, s.RandomBytesTotal, created, s.ID, s.Label)
+		ch <- newCounter(c.target, c.ReadIopsTotal, s.ReadIopsTotal, created, s.ID, s.Label)
+		ch <- newCounter(c.target, c.ReadBytesTotal, s.ReadBytesTotal, created, s.ID, s.Label)
+		ch <- newCounter(c.target, c.WriteIopsTotal, s.WriteIopsTotal, created, s.ID, s.Label)
+		ch <- newCounter(c.target, c.WriteBytesTotal, s.WriteBytesTotal, created, s.ID, s.Label)
+		ch <- newCounter(c.target, c.MirrorIopsTotal, s.MirrorIopsTotal, created, s.ID, s.Label)
+		ch <- newCounter(c.target, c.MirrorBytesTotal, s.MirrorBytesTotal, created, s.ID, s.Label)
+		ch <- newCounter(c.target, c.FullStripeWritesBytes, s.FullStripeWritesBytes, created, s.ID, s.Label)
+		ch <- newCounter(c.target, c.Raid0BytesTransferred, s.Raid0BytesTransferred, created, s.ID, s.Label)
+		ch <- newCounter(c.target, c.Raid1BytesTransferred, s.Raid1BytesTransferred, created, s.ID, s.Label)
+		ch <- newCounter(c.target, c.Raid5BytesTransferred, s.Raid5BytesTransferred, created, s.ID, s.Label)
+		ch <- newCounter(c.target, c.Raid6BytesTransferred, s.Raid6BytesTransferred, created, s.ID, s.Label)
+		ch <- newCounter(c.target, c.DdpBytesTransferred, s.DdpBytesTransferred, created, s.ID, s.Label)
+		// Estimates of the current capacity, not totals since the last reset
+		ch <- prometheus.MustNewConstMetric(c.MaxPossibleBpsUnderCurrentLoad, prometheus.GaugeValue, s.MaxPossibleBpsUnderCurrentLoad, s.ID, s.Label)
+		ch <- prometheus.MustNewConstMetric(c.MaxPossibleIopsUnderCurrentLoad, prometheus.GaugeValue, s.MaxPossibleIopsUnderCurrentLoad, s.ID, s.Label)
+		for core, cpu := range s.CpuUtilizationStats {
+			ch <- prometheus.MustNewConstMetric(c.CorePeakCpuUtilization, prometheus.GaugeValue, cpu.MaxCpuUtilization, s.ID, s.Label, strconv.Itoa(core))
+		}
+	}
+
+	// A failed collection lists no objects, only a complete one shows which are gone
+	if err == nil {
+		statisticsResets.prune(c.target.Name+"/controller/", seen)
 	}
 
 	ch <- prometheus.MustNewConstMetric(collectError, prometheus.GaugeValue, float64(errorMetric), "controller-statistics")
@@ -302,12 +527,40 @@ func (c *ControllerStatisticsCollector) collect() ([]AnalysedControllerStatistic
 			s.Label = controller.Label
 		}
 		// Convert milliseconds to seconds
-		s.CombinedResponseTime = s.CombinedResponseTime * 0.001
 		s.ReadResponseTime = s.ReadResponseTime * 0.001
+		s.ReadResponseTimeStdDev = s.ReadResponseTimeStdDev * 0.001
 		s.WriteResponseTime = s.WriteResponseTime * 0.001
+		s.WriteResponseTimeStdDev = s.WriteResponseTimeStdDev * 0.001
+		s.CombinedResponseTime = s.CombinedResponseTime * 0.001
+		s.CombinedResponseTimeStdDev = s.CombinedResponseTimeStdDev * 0.001
+		s.ReadHitResponseTime = s.ReadHitResponseTime * 0.001
+		s.ReadHitResponseTimeStdDev = s.ReadHitResponseTimeStdDev * 0.001
+		s.WriteHitResponseTime = s.WriteHitResponseTime * 0.001
+		s.WriteHitResponseTimeStdDev = s.WriteHitResponseTimeStdDev * 0.001
+		s.CombinedHitResponseTime = s.CombinedHitResponseTime * 0.001
+		s.CombinedHitResponseTimeStdDev = s.CombinedHitResponseTimeStdDev * 0.001
+		// Convert MiB/s to bytes/s
+		s.ReadThroughput = s.ReadThroughput * 1024 * 1024
+		s.WriteThroughput = s.WriteThroughput * 1024 * 1024
+		s.CombinedThroughput = s.CombinedThroughput * 1024 * 1024
 		// Convert from percent to ratio
+		s.CacheHitBytesPercent = s.CacheHitBytesPercent / 100
+		s.RandomIosPercent = s.RandomIosPercent / 100
+		s.MirrorBytesPercent = s.MirrorBytesPercent / 100
+		s.FullStripeWritesBytesPercent = s.FullStripeWritesBytesPercent / 100
 		s.MaxCpuUtilization = s.MaxCpuUtilization / 100
 		s.CpuAvgUtilization = s.CpuAvgUtilization / 100
+		s.Raid0BytesPercent = s.Raid0BytesPercent / 100
+		s.Raid1BytesPercent = s.Raid1BytesPercent / 100
+		s.Raid5BytesPercent = s.Raid5BytesPercent / 100
+		s.Raid6BytesPercent = s.Raid6BytesPercent / 100
+		s.DdpBytesPercent = s.DdpBytesPercent / 100
+		for core := range s.CpuAvgUtilizationPerCore {
+			s.CpuAvgUtilizationPerCore[core] = s.CpuAvgUtilizationPerCore[core] / 100
+		}
+		for core := range s.MaxCpuUtilizationPerCore {
+			s.MaxCpuUtilizationPerCore[core] = s.MaxCpuUtilizationPerCore[core] / 100
+		}
 	}
 	for i := range statistics {
 		s := &statistics[i]
@@ -315,6 +568,10 @@ func (c *ControllerStatisticsCollector) collect() ([]AnalysedControllerStatistic
 		if ok {
 			s.Label = controller.Label
 		}
+		// Convert from percent to ratio
+		for core := range s.CpuUtilizationStats {
+			s.CpuUtilizationStats[core].MaxCpuUtilization = s.CpuUtilizationStats[core].MaxCpuUtilization / 100
+		}
 	}
 	return analyzedStatistics, statistics, nil
 }
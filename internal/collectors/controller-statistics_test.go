@@ -29,8 +29,52 @@ func TestControllerStatisticsCollector(t *testing.T) {
 	}
 	expected := `# HELP eseries_controller_average_read_op_size_bytes Controller statistic averageReadOpSize
 # TYPE eseries_controller_average_read_op_size_bytes gauge
-eseries_controller_average_read_op_size_bytes{controller="070000000000000000000001",controller_label="A"} 39687.27392305163
-eseries_controller_average_read_op_size_bytes{controller="070000000000000000000002",controller_label="B"} 73664.54585344449
+eseries_controller_average_read_op_size_bytes{controller="070000000000000000000001",controller_label="A",source_controller=""} 39687.27392305163
+eseries_controller_average_read_op_size_bytes{controller="070000000000000000000002",controller_label="B",source_controller=""} 73664.54585344449
+# HELP eseries_controller_combined_iops Controller statistic combinedIOps
+# TYPE eseries_controller_combined_iops gauge
+eseries_controller_combined_iops{controller="070000000000000000000001",controller_label="A",source_controller=""} 138.11666666666667
+eseries_controller_combined_iops{controller="070000000000000000000002",controller_label="B",source_controller=""} 70.65
+# HELP eseries_controller_cpu_core_max_utilization_ratio Controller statistic maxCpuUtilizationPerCore (0.0-1.0 ratio of CPU percent utilization)
+# TYPE eseries_controller_cpu_core_max_utilization_ratio gauge
+eseries_controller_cpu_core_max_utilization_ratio{controller="070000000000000000000001",controller_label="A",core="0",source_controller=""} 0.04
+eseries_controller_cpu_core_max_utilization_ratio{controller="070000000000000000000001",controller_label="A",core="1",source_controller=""} 0.11
+eseries_controller_cpu_core_max_utilization_ratio{controller="070000000000000000000001",controller_label="A",core="2",source_controller=""} 0.03
+eseries_controller_cpu_core_max_utilization_ratio{controller="070000000000000000000001",controller_label="A",core="3",source_controller=""} 0.01
+eseries_controller_cpu_core_max_utilization_ratio{controller="070000000000000000000002",controller_label="B",core="0",source_controller=""} 0.37
+eseries_controller_cpu_core_max_utilization_ratio{controller="070000000000000000000002",controller_label="B",core="1",source_controller=""} 0.24
+eseries_controller_cpu_core_max_utilization_ratio{controller="070000000000000000000002",controller_label="B",core="2",source_controller=""} 0.27
+eseries_controller_cpu_core_max_utilization_ratio{controller="070000000000000000000002",controller_label="B",core="3",source_controller=""} 0.01
+# HELP eseries_controller_cpu_core_peak_utilization_ratio Controller statistic cpuUtilizationStats maxCpuUtilization since the last statistics reset (0.0-1.0 ratio of CPU percent utilization)
+# TYPE eseries_controller_cpu_core_peak_utilization_ratio gauge
+eseries_controller_cpu_core_peak_utilization_ratio{controller="070000000000000000000001",controller_label="A",core="0"} 0.04
+eseries_controller_cpu_core_peak_utilization_ratio{controller="070000000000000000000001",controller_label="A",core="1"} 0.11
+eseries_controller_cpu_core_peak_utilization_ratio{controller="070000000000000000000001",controller_label="A",core="2"} 0.03
+eseries_controller_cpu_core_peak_utilization_ratio{controller="070000000000000000000001",controller_label="A",core="3"} 0.01
+eseries_controller_cpu_core_peak_utilization_ratio{controller="070000000000000000000002",controller_label="B",core="0"} 0.37
+eseries_controller_cpu_core_peak_utilization_ratio{controller="070000000000000000000002",controller_label="B",core="1"} 0.24
+eseries_controller_cpu_core_peak_utilization_ratio{controller="070000000000000000000002",controller_label="B",core="2"} 0.27
+eseries_controller_cpu_core_peak_utilization_ratio{controller="070000000000000000000002",controller_label="B",core="3"} 0.01
+# HELP eseries_controller_last_reset_timestamp_seconds Controller statistic lastResetTime, the Unix time the controller counters were last reset
+# TYPE eseries_controller_last_reset_timestamp_seconds gauge
+eseries_controller_last_reset_timestamp_seconds{controller="070000000000000000000001",controller_label="A"} 1604971911
+eseries_controller_last_reset_timestamp_seconds{controller="070000000000000000000002",controller_label="B"} 1604971912
+# HELP eseries_controller_random_ios_ratio Controller statistic randomIosPercent (0.0-1.0 ratio of random I/O)
+# TYPE eseries_controller_random_ios_ratio gauge
+eseries_controller_random_ios_ratio{controller="070000000000000000000001",controller_label="A",source_controller=""} 0.34017135272112945
+eseries_controller_random_ios_ratio{controller="070000000000000000000002",controller_label="B",source_controller=""} 0.38650306748466257
+# HELP eseries_controller_raid_bytes_ratio Controller statistic raid0/1/5/6 and ddp BytesPercent (0.0-1.0 ratio of bytes transferred per RAID level)
+# TYPE eseries_controller_raid_bytes_ratio gauge
+eseries_controller_raid_bytes_ratio{controller="070000000000000000000001",controller_label="A",raid_level="raid0",source_controller=""} 0
+eseries_controller_raid_bytes_ratio{controller="070000000000000000000001",controller_label="A",raid_level="raid1",source_controller=""} 0
+eseries_controller_raid_bytes_ratio{controller="070000000000000000000001",controller_label="A",raid_level="raid5",source_controller=""} 0
+eseries_controller_raid_bytes_ratio{controller="070000000000000000000001",controller_label="A",raid_level="raid6",source_controller=""} 0
+eseries_controller_raid_bytes_ratio{controller="070000000000000000000001",controller_label="A",raid_level="raidDiskPool",source_controller=""} 0.8022266685675612
+eseries_controller_raid_bytes_ratio{controller="070000000000000000000002",controller_label="B",raid_level="raid0",source_controller=""} 0
+eseries_controller_raid_bytes_ratio{controller="070000000000000000000002",controller_label="B",raid_level="raid1",source_controller=""} 0
+eseries_controller_raid_bytes_ratio{controller="070000000000000000000002",controller_label="B",raid_level="raid5",source_controller=""} 0
+eseries_controller_raid_bytes_ratio{controller="070000000000000000000002",controller_label="B",raid_level="raid6",source_controller=""} 0
+eseries_controller_raid_bytes_ratio{controller="070000000000000000000002",controller_label="B",raid_level="raidDiskPool",source_controller=""} 0.44245142499414655
 # HELP eseries_exporter_collect_error Indicates if error has occurred during collection
 # TYPE eseries_exporter_collect_error gauge
 eseries_exporter_collect_error{collector="controller-statistics"} 0
@@ -58,12 +102,14 @@ eseries_exporter_collect_error{collector="controller-statistics"} 0
 	gatherers := setupGatherer(collector)
 	if val, err := testutil.GatherAndCount(gatherers); err != nil {
 		t.Errorf("Unexpected error: %v", err)
-	} else if val != 56 {
-		t.Errorf("Unexpected collection count %d, expected 56", val)
+	} else if val != 143 {
+		t.Errorf("Unexpected collection count %d, expected 143", val)
 	}
 	if err := testutil.GatherAndCompare(gatherers, strings.NewReader(expected),
-		"eseries_controller_average_read_op_size_bytes",
-		"eseries_exporter_collect_error"); err != nil {
+		"eseries_controller_average_read_op_size_bytes", "eseries_controller_combined_iops",
+		"eseries_controller_random_ios_ratio", "eseries_controller_cpu_core_max_utilization_ratio",
+		"eseries_controller_cpu_core_peak_utilization_ratio", "eseries_controller_last_reset_timestamp_seconds",
+		"eseries_controller_raid_bytes_ratio", "eseries_exporter_collect_error"); err != nil {
 		t.Errorf("unexpected collecting result:\n%s", err)
 	}
 }
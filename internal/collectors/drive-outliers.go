@@ -0,0 +1,217 @@
+package collector
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/sckyzo/eseries_exporter/internal/config"
+)
+
+// driveOutlierMinPeers is the smallest group of drives worth comparing; with fewer
+// peers the median says little about what a healthy drive looks like.
+const driveOutlierMinPeers = 3
+
+// Differences from the median below these are scored 0: with nearly identical
+// peers the deviation is tiny and any noise would otherwise score high.
+const (
+	driveOutlierMinResponseTimeDelta = 5 // milliseconds
+	driveOutlierMinQueueDepthDelta   = 1
+)
+
+// driveOutlierPeer is one drive of a pool and media type group with the analysed
+// statistics it is compared on.
+type driveOutlierPeer struct {
+	Drive        Drive
+	Pool         string
+	MediaType    string
+	ResponseTime float64
+	QueueDepth   float64
+}
+
+type DriveOutliersCollector struct {
+	Score   *prometheus.Desc
+	Outlier *prometheus.Desc
+	target  config.Target
+	logger  *slog.Logger
+}
+
+func init() {
+	registerCollector("drive-outliers", false, NewDriveOutliersExporter)
+}
+
+func NewDriveOutliersExporter(target config.Target, logger *slog.Logger) Collector {
+	labels := []string{"tray", "slot", "pool", "media_type"}
+	return &DriveOutliersCollector{
+		Score: prometheus.NewDesc(prometheus.BuildFQName(namespace, "drive", "outlier_score"),
+			"Robust z-score of the drive against the median of its pool and media type peers", append(labels, "metric"), nil),
+		Outlier: prometheus.NewDesc(prometheus.BuildFQName(namespace, "drive", "outlier"),
+			"Indicates if the drive is slower than its peers beyond the configured threshold", labels, nil),
+		target: target,
+		logger: logger,
+	}
+}
+
+func (c *DriveOutliersCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.Score
+	ch <- c.Outlier
+}
+
+func (c *DriveOutliersCollector) Collect(ch chan<- prometheus.Metric) {
+	c.logger.Debug("Collecting drive-outliers metrics")
+	collectTime := time.Now()
+	var errorMetric int
+	groups, err := c.collect()
+	if err != nil {
+		c.logger.Error("Collection failed", "error", err)
+		errorMetric = 1
+	}
+
+	for _, peers := range groups {
+		if len(peers) < driveOutlierMinPeers {
+			continue
+		}
+		responseTimes := make([]float64, len(peers))
+		queueDepths := make([]float64, len(peers))
+		for i, p := range peers {
+			responseTimes[i] = p.ResponseTime
+			queueDepths[i] = p.QueueDepth
+		}
+		responseTimeScores := outlierScores(responseTimes, driveOutlierMinResponseTimeDelta)
+		queueDepthScores := outlierScores(queueDepths, driveOutlierMinQueueDepthDelta)
+		for i, p := range peers {
+			var outlier float64
+			// Only drives slower than their peers are of interest
+			if responseTimeScores[i] > c.target.DriveOutlierThreshold || queueDepthScores[i] > c.target.DriveOutlierThreshold {
+				outlier = 1
+			}
+			ch <- prometheus.MustNewConstMetric(c.Score, prometheus.GaugeValue, responseTimeScores[i], p.Drive.TrayID, p.Drive.Slot, p.Pool, p.MediaType, "response_time")
+			ch <- prometheus.MustNewConstMetric(c.Score, prometheus.GaugeValue, queueDepthScores[i], p.Drive.TrayID, p.Drive.Slot, p.Pool, p.MediaType, "queue_depth")
+			ch <- prometheus.MustNewConstMetric(c.Outlier, prometheus.GaugeValue, outlier, p.Drive.TrayID, p.Drive.Slot, p.Pool, p.MediaType)
+		}
+	}
+
+	ch <- prometheus.MustNewConstMetric(collectError, prometheus.GaugeValue, float64(errorMetric), "drive-outliers")
+	ch <- prometheus.MustNewConstMetric(collectDuration, prometheus.GaugeValue, time.Since(collectTime).Seconds(), "drive-outliers")
+}
+
+func (c *DriveOutliersCollector) collect() (map[string][]driveOutlierPeer, error) {
+	var inventory DrivesInventory
+	var pools []StoragePool
+	var statistics []AnalysedDriveStatistics
+	bodies, err := getRequests(c.target, []string{
+		fmt.Sprintf("/devmgr/v2/storage-systems/%s/hardware-inventory", c.target.Name),
+		fmt.Sprintf("/devmgr/v2/storage-systems/%s/storage-pools", c.target.Name),
+		fmt.Sprintf("/devmgr/v2/storage-systems/%s/analysed-drive-statistics", c.target.Name),
+	}, c.logger)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(bodies[0], &inventory); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal hardware inventory: %w", err)
+	}
+	if err := json.Unmarshal(bodies[1], &pools); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal storage pools: %w", err)
+	}
+	if err := json.Unmarshal(bodies[2], &statistics); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal drive statistics: %w", err)
+	}
+
+	poolLabels := make(map[string]string)
+	for _, p := range pools {
+		poolLabels[p.ID] = p.Label
+	}
+
+	trays := make(map[string]int)
+	for _, t := range inventory.Trays {
+		trays[t.TrayRef] = t.ID
+	}
+	drives := make(map[string]Drive)
+	for _, d := range inventory.Drives {
+		if trayId, ok := trays[d.PhysicalLocation.TrayRef]; ok {
+			d.TrayID = strconv.Itoa(trayId)
+		}
+		d.Slot = strconv.Itoa(d.PhysicalLocation.Slot)
+		drives[d.ID] = d
+	}
+
+	// Unassigned drives carry no load and have no peers to compare with
+	observations := newStatisticsObservations(c.target)
+	groups := make(map[string][]driveOutlierPeer)
+	for _, s := range statistics {
+		drive, ok := drives[s.ID]
+		if !ok {
+			continue
+		}
+		pool, ok := poolLabels[drive.CurrentVolumeGroupRef]
+		if !ok {
+			continue
+		}
+		if observed, fresh := observations.observe(s.ObservedTimeInMS); !fresh {
+			c.logger.Debug("Skipping stale drive statistics", "drive", s.ID, "observed", observed)
+			continue
+		}
+		key := drive.CurrentVolumeGroupRef + "/" + drive.DriveMediaType
+		groups[key] = append(groups[key], driveOutlierPeer{
+			Drive:        drive,
+			Pool:         pool,
+			MediaType:    drive.DriveMediaType,
+			ResponseTime: s.CombinedResponseTime,
+			QueueDepth:   s.AverageQueueDepth,
+		})
+	}
+	return groups, nil
+}
+
+// outlierScores returns the modified z-score of each value, based on the median
+// absolute deviation (MAD) so a single slow drive cannot hide itself by skewing
+// the baseline. When more than half the values are identical the MAD is zero and
+// the mean absolute deviation is used instead. Values within minDelta of the
+// median score 0, as do all values of an idle group whose median is 0.
+func outlierScores(values []float64, minDelta float64) []float64 {
+	scores := make([]float64, len(values))
+	m := median(values)
+	if m == 0 {
+		return scores
+	}
+	deviations := make([]float64, len(values))
+	var sum float64
+	for i, v := range values {
+		deviations[i] = math.Abs(v - m)
+		sum += deviations[i]
+	}
+	var scale float64
+	if mad := median(deviations); mad > 0 {
+		scale = mad / 0.6745
+	} else if meanAD := sum / float64(len(values)); meanAD > 0 {
+		scale = meanAD * 1.253314
+	} else {
+		return scores
+	}
+	for i, v := range values {
+		if math.Abs(v-m) < minDelta {
+			continue
+		}
+		scores[i] = (v - m) / scale
+	}
+	return scores
+}
+
+func median(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	middle := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[middle-1] + sorted[middle]) / 2
+	}
+	return sorted[middle]
+}
@@ -0,0 +1,157 @@
+package collector
+
+import (
+	"log/slog"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/sckyzo/eseries_exporter/internal/config"
+)
+
+func TestOutlierScores(t *testing.T) {
+	scores := outlierScores([]float64{10, 11, 9, 10, 50}, 0)
+	for i, expected := range []float64{0, 0.6745, -0.6745, 0, 26.98} {
+		if math.Abs(scores[i]-expected) > 0.0001 {
+			t.Errorf("Unexpected score %v at %d, expected %v", scores[i], i, expected)
+		}
+	}
+	// Differences below the minimum delta are not scored
+	scores = outlierScores([]float64{10, 11, 9, 10, 50}, 5)
+	for i, expected := range []float64{0, 0, 0, 0, 26.98} {
+		if math.Abs(scores[i]-expected) > 0.0001 {
+			t.Errorf("Unexpected score %v at %d, expected %v", scores[i], i, expected)
+		}
+	}
+	// More than half the values are identical so the MAD is zero
+	scores = outlierScores([]float64{5, 5, 5, 5, 20}, 5)
+	if math.Abs(scores[4]-3.989) > 0.001 {
+		t.Errorf("Unexpected score %v, expected 3.989", scores[4])
+	}
+	for _, score := range outlierScores([]float64{5, 5, 5, 5, 6}, 5) {
+		if score != 0 {
+			t.Errorf("Unexpected score %v for a delta below the minimum, expected 0", score)
+		}
+	}
+	for _, score := range outlierScores([]float64{5, 5, 5}, 5) {
+		if score != 0 {
+			t.Errorf("Unexpected score %v for identical values, expected 0", score)
+		}
+	}
+}
+
+func TestOutlierScoresIdle(t *testing.T) {
+	values := make([]float64, 12)
+	values[11] = 0.01
+	for i, score := range outlierScores(values, 0) {
+		if score != 0 {
+			t.Errorf("Unexpected score %v at %d for an idle group, expected 0", score, i)
+		}
+	}
+}
+
+func TestDriveOutliersCollector(t *testing.T) {
+	fixtures := make(map[string][]byte)
+	for path, file := range map[string]string{
+		"hardware-inventory":        "testdata/drive-outliers-inventory.json",
+		"storage-pools":             "testdata/storage-pools-response.json",
+		"analysed-drive-statistics": "testdata/drive-outliers-statistics.json",
+	} {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatalf("Error loading fixture data: %s", err.Error())
+		}
+		fixtures[path] = data
+	}
+	expected := `# HELP eseries_drive_outlier Indicates if the drive is slower than its peers beyond the configured threshold
+# TYPE eseries_drive_outlier gauge
+eseries_drive_outlier{media_type="hdd",pool="Pool_1",slot="1",tray="0"} 0
+eseries_drive_outlier{media_type="hdd",pool="Pool_1",slot="2",tray="0"} 0
+eseries_drive_outlier{media_type="hdd",pool="Pool_1",slot="3",tray="0"} 0
+eseries_drive_outlier{media_type="hdd",pool="Pool_1",slot="4",tray="0"} 0
+eseries_drive_outlier{media_type="hdd",pool="Pool_1",slot="5",tray="0"} 1
+# HELP eseries_drive_outlier_score Robust z-score of the drive against the median of its pool and media type peers
+# TYPE eseries_drive_outlier_score gauge
+eseries_drive_outlier_score{media_type="hdd",metric="queue_depth",pool="Pool_1",slot="1",tray="0"} 0
+eseries_drive_outlier_score{media_type="hdd",metric="queue_depth",pool="Pool_1",slot="2",tray="0"} 0
+eseries_drive_outlier_score{media_type="hdd",metric="queue_depth",pool="Pool_1",slot="3",tray="0"} 0
+eseries_drive_outlier_score{media_type="hdd",metric="queue_depth",pool="Pool_1",slot="4",tray="0"} 0
+eseries_drive_outlier_score{media_type="hdd",metric="queue_depth",pool="Pool_1",slot="5",tray="0"} 0
+eseries_drive_outlier_score{media_type="hdd",metric="response_time",pool="Pool_1",slot="1",tray="0"} 0
+eseries_drive_outlier_score{media_type="hdd",metric="response_time",pool="Pool_1",slot="2",tray="0"} 0
+eseries_drive_outlier_score{media_type="hdd",metric="response_time",pool="Pool_1",slot="3",tray="0"} 0
+eseries_drive_outlier_score{media_type="hdd",metric="response_time",pool="Pool_1",slot="4",tray="0"} 0
+eseries_drive_outlier_score{media_type="hdd",metric="response_time",pool="Pool_1",slot="5",tray="0"} 26.98
+# HELP eseries_exporter_collect_error Indicates if error has occurred during collection
+# TYPE eseries_exporter_collect_error gauge
+eseries_exporter_collect_error{collector="drive-outliers"} 0
+`
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		path := strings.TrimPrefix(req.URL.Path, "/devmgr/v2/storage-systems/test/")
+		data, ok := fixtures[path]
+		if !ok {
+			http.Error(rw, "not found", http.StatusNotFound)
+			return
+		}
+		_, _ = rw.Write(data)
+	}))
+	defer server.Close()
+	baseURL, _ := url.Parse(server.URL)
+	target := config.Target{
+		Name:                  "test",
+		User:                  "test",
+		Password:              "test",
+		BaseURL:               baseURL,
+		HttpClient:            &http.Client{},
+		DriveOutlierThreshold: 3.5,
+	}
+	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+	collector := NewDriveOutliersExporter(target, logger)
+	gatherers := setupGatherer(collector)
+	if val, err := testutil.GatherAndCount(gatherers); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	} else if val != 17 {
+		t.Errorf("Unexpected collection count %d, expected 17", val)
+	}
+	if err := testutil.GatherAndCompare(gatherers, strings.NewReader(expected),
+		"eseries_drive_outlier", "eseries_drive_outlier_score", "eseries_exporter_collect_error"); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+}
+
+func TestDriveOutliersCollectorError(t *testing.T) {
+	expected := `# HELP eseries_exporter_collect_error Indicates if error has occurred during collection
+# TYPE eseries_exporter_collect_error gauge
+eseries_exporter_collect_error{collector="drive-outliers"} 1
+`
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		http.Error(rw, "error", http.StatusNotFound)
+	}))
+	defer server.Close()
+	baseURL, _ := url.Parse(server.URL)
+	target := config.Target{
+		Name:       "test",
+		User:       "test",
+		Password:   "test",
+		BaseURL:    baseURL,
+		HttpClient: &http.Client{},
+	}
+	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+	collector := NewDriveOutliersExporter(target, logger)
+	gatherers := setupGatherer(collector)
+	if val, err := testutil.GatherAndCount(gatherers); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	} else if val != 2 {
+		t.Errorf("Unexpected collection count %d, expected 2", val)
+	}
+	if err := testutil.GatherAndCompare(gatherers, strings.NewReader(expected),
+		"eseries_drive_outlier", "eseries_exporter_collect_error"); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+}
@@ -14,18 +14,32 @@ import (
 )
 
 type AnalysedDriveStatistics struct {
-	ID                   string  `json:"diskId"`
-	AverageReadOpSize    float64 `json:"averageReadOpSize"`
-	AverageWriteOpSize   float64 `json:"averageWriteOpSize"`
-	CombinedResponseTime float64 `json:"combinedResponseTime"`
-	ReadPhysicalIOps     float64 `json:"readPhysicalIOps"`
-	ReadResponseTime     float64 `json:"readResponseTime"`
-	WritePhysicalIOps    float64 `json:"writePhysicalIOps"`
-	WriteResponseTime    float64 `json:"writeResponseTime"`
+	ID                         string  `json:"diskId"`
+	ObservedTimeInMS           string  `json:"observedTimeInMS"`
+	AverageReadOpSize          float64 `json:"averageReadOpSize"`
+	AverageWriteOpSize         float64 `json:"averageWriteOpSize"`
+	CombinedResponseTime       float64 `json:"combinedResponseTime"`
+	ReadPhysicalIOps           float64 `json:"readPhysicalIOps"`
+	ReadResponseTime           float64 `json:"readResponseTime"`
+	WritePhysicalIOps          float64 `json:"writePhysicalIOps"`
+	WriteResponseTime          float64 `json:"writeResponseTime"`
+	CombinedIOps               float64 `json:"combinedIOps"`
+	ReadThroughput             float64 `json:"readThroughput"`
+	WriteThroughput            float64 `json:"writeThroughput"`
+	CombinedThroughput         float64 `json:"combinedThroughput"`
+	ReadResponseTimeStdDev     float64 `json:"readResponseTimeStdDev"`
+	WriteResponseTimeStdDev    float64 `json:"writeResponseTimeStdDev"`
+	CombinedResponseTimeStdDev float64 `json:"combinedResponseTimeStdDev"`
+	ReadTimeMax                float64 `json:"readTimeMax"`
+	WriteTimeMax               float64 `json:"writeTimeMax"`
+	AverageQueueDepth          float64 `json:"averageQueueDepth"`
+	QueueDepthMax              float64 `json:"queueDepthMax"`
+	RandomIosPercent           float64 `json:"randomIosPercent"`
 }
 
 type DriveStatistics struct {
 	ID                string  `json:"diskId"`
+	LastResetTimeInMS string  `json:"lastResetTimeInMS"`
 	IdleTime          float64 `json:"idleTime"`
 	OtherOPs          float64 `json:"otherOps"`
 	OtherTimeTotal    float64 `json:"otherTimeTotal"`
@@ -45,31 +59,45 @@ type DriveStatistics struct {
 }
 
 type DriveStatisticsCollector struct {
-	AverageReadOpSize    *prometheus.Desc
-	AverageWriteOpSize   *prometheus.Desc
-	CombinedResponseTime *prometheus.Desc
-	ReadPhysicalIOps     *prometheus.Desc
-	ReadResponseTime     *prometheus.Desc
-	WritePhysicalIOps    *prometheus.Desc
-	WriteResponseTime    *prometheus.Desc
-	IdleTime             *prometheus.Desc
-	OtherOPs             *prometheus.Desc
-	OtherTimeTotal       *prometheus.Desc
-	ReadBytes            *prometheus.Desc
-	ReadOPs              *prometheus.Desc
-	ReadTimeTotal        *prometheus.Desc
-	RecoveredErrors      *prometheus.Desc
-	RetriedIOs           *prometheus.Desc
-	Timeouts             *prometheus.Desc
-	UnrecoveredErrors    *prometheus.Desc
-	WriteBytes           *prometheus.Desc
-	WriteOPs             *prometheus.Desc
-	WriteTimeTotal       *prometheus.Desc
-	QueueDepthTotal      *prometheus.Desc
-	RandomIOsTotal       *prometheus.Desc
-	RandomBytesTotal     *prometheus.Desc
-	target               config.Target
-	logger               *slog.Logger
+	AverageReadOpSize          *prometheus.Desc
+	AverageWriteOpSize         *prometheus.Desc
+	CombinedResponseTime       *prometheus.Desc
+	ReadPhysicalIOps           *prometheus.Desc
+	ReadResponseTime           *prometheus.Desc
+	WritePhysicalIOps          *prometheus.Desc
+	WriteResponseTime          *prometheus.Desc
+	CombinedIOps               *prometheus.Desc
+	ReadThroughput             *prometheus.Desc
+	WriteThroughput            *prometheus.Desc
+	CombinedThroughput         *prometheus.Desc
+	ReadResponseTimeStdDev     *prometheus.Desc
+	WriteResponseTimeStdDev    *prometheus.Desc
+	CombinedResponseTimeStdDev *prometheus.Desc
+	ReadTimeMax                *prometheus.Desc
+	WriteTimeMax               *prometheus.Desc
+	AverageQueueDepth          *prometheus.Desc
+	QueueDepthMax              *prometheus.Desc
+	RandomIosPercent           *prometheus.Desc
+	IdleTime                   *prometheus.Desc
+	OtherOPs                   *prometheus.Desc
+	OtherTimeTotal             *prometheus.Desc
+	ReadBytes                  *prometheus.Desc
+	ReadOPs                    *prometheus.Desc
+	ReadTimeTotal              *prometheus.Desc
+	RecoveredErrors            *prometheus.Desc
+	RetriedIOs                 *prometheus.Desc
+	Timeouts                   *prometheus.Desc
+	UnrecoveredErrors          *prometheus.Desc
+	WriteBytes                 *prometheus.Desc
+	WriteOPs                   *prometheus.Desc
+	WriteTimeTotal             *prometheus.Desc
+	QueueDepthTotal            *prometheus.Desc
+	RandomIOsTotal             *prometheus.Desc
+	RandomBytesTotal           *prometheus.Desc
+	LastResetTime              *prometheus.Desc
+	StatisticsResets           *prometheus.Desc
+	target                     config.Target
+	logger                     *slog.Logger
 }
 
 func init() {
@@ -92,6 +120,30 @@ func NewDriveStatisticsExporter(target config.Target, logger *slog.Logger) Colle
 			"Drive statistic writePhysicalIOps", []string{"tray", "slot"}, nil),
 		WriteResponseTime: prometheus.NewDesc(prometheus.BuildFQName(namespace, "drive", "write_response_time_seconds"),
 			"Drive statistic writeResponseTime", []string{"tray", "slot"}, nil),
+		CombinedIOps: prometheus.NewDesc(prometheus.BuildFQName(namespace, "drive", "combined_iops"),
+			"Drive statistic combinedIOps", []string{"tray", "slot"}, nil),
+		ReadThroughput: prometheus.NewDesc(prometheus.BuildFQName(namespace, "drive", "read_throughput_bytes_per_second"),
+			"Drive statistic readThroughput", []string{"tray", "slot"}, nil),
+		WriteThroughput: prometheus.NewDesc(prometheus.BuildFQName(namespace, "drive", "write_throughput_bytes_per_second"),
+			"Drive statistic writeThroughput", []string{"tray", "slot"}, nil),
+		CombinedThroughput: prometheus.NewDesc(prometheus.BuildFQName(namespace, "drive", "combined_throughput_bytes_per_second"),
+			"Drive statistic combinedThroughput", []string{"tray", "slot"}, nil),
+		ReadResponseTimeStdDev: prometheus.NewDesc(prometheus.BuildFQName(namespace, "drive", "read_response_time_stddev_seconds"),
+			"Drive statistic readResponseTimeStdDev", []string{"tray", "slot"}, nil),
+		WriteResponseTimeStdDev: prometheus.NewDesc(prometheus.BuildFQName(namespace, "drive", "write_response_time_stddev_seconds"),
+			"Drive statistic writeResponseTimeStdDev", []string{"tray", "slot"}, nil),
+		CombinedResponseTimeStdDev: prometheus.NewDesc(prometheus.BuildFQName(namespace, "drive", "combined_response_time_stddev_seconds"),
+			"Drive statistic combinedResponseTimeStdDev", []string{"tray", "slot"}, nil),
+		ReadTimeMax: prometheus.NewDesc(prometheus.BuildFQName(namespace, "drive", "read_time_max_seconds"),
+			"Drive statistic readTimeMax", []string{"tray", "slot"}, nil),
+		WriteTimeMax: prometheus.NewDesc(prometheus.BuildFQName(namespace, "drive", "write_time_max_seconds"),
+			"Drive statistic writeTimeMax", []string{"tray", "slot"}, nil),
+		AverageQueueDepth: prometheus.NewDesc(prometheus.BuildFQName(namespace, "drive", "average_queue_depth"),
+			"Drive statistic averageQueueDepth", []string{"tray", "slot"}, nil),
+		QueueDepthMax: prometheus.NewDesc(prometheus.BuildFQName(namespace, "drive", "queue_depth_max"),
+			"Drive statistic queueDepthMax", []string{"tray", "slot"}, nil),
+		RandomIosPercent: prometheus.NewDesc(prometheus.BuildFQName(namespace, "drive", "random_ios_ratio"),
+			"Drive statistic randomIosPercent (0.0-1.0 ratio of random I/O)", []string{"tray", "slot"}, nil),
 		IdleTime: prometheus.NewDesc(prometheus.BuildFQName(namespace, "drive", "idle_time_seconds_total"),
 			"Drive statistic idleTime", []string{"tray", "slot"}, nil),
 		OtherOPs: prometheus.NewDesc(prometheus.BuildFQName(namespace, "drive", "other_ops_total"),
@@ -124,6 +176,10 @@ func NewDriveStatisticsExporter(target config.Target, logger *slog.Logger) Colle
 			"Drive statistic randomIosTotal", []string{"tray", "slot"}, nil),
 		RandomBytesTotal: prometheus.NewDesc(prometheus.BuildFQName(namespace, "drive", "random_bytes_total"),
 			"Drive statistic randomBytesTotal", []string{"tray", "slot"}, nil),
+		LastResetTime: prometheus.NewDesc(prometheus.BuildFQName(namespace, "drive", "last_reset_timestamp_seconds"),
+			"Drive statistic lastResetTime, the Unix time the drive counters were last reset", []string{"tray", "slot"}, nil),
+		StatisticsResets: prometheus.NewDesc(prometheus.BuildFQName(namespace, "drive", "statistics_resets_total"),
+			"Drive statistics resets observed by the exporter", []string{"tray", "slot"}, nil),
 		target: target,
 		logger: logger,
 	}
@@ -137,6 +193,18 @@ func (c *DriveStatisticsCollector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- c.ReadResponseTime
 	ch <- c.WritePhysicalIOps
 	ch <- c.WriteResponseTime
+	ch <- c.CombinedIOps
+	ch <- c.ReadThroughput
+	ch <- c.WriteThroughput
+	ch <- c.CombinedThroughput
+	ch <- c.ReadResponseTimeStdDev
+	ch <- c.WriteResponseTimeStdDev
+	ch <- c.CombinedResponseTimeStdDev
+	ch <- c.ReadTimeMax
+	ch <- c.WriteTimeMax
+	ch <- c.AverageQueueDepth
+	ch <- c.QueueDepthMax
+	ch <- c.RandomIosPercent
 	ch <- c.IdleTime
 	ch <- c.OtherOPs
 	ch <- c.OtherTimeTotal
@@ -153,6 +221,8 @@ func (c *DriveStatisticsCollector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- c.QueueDepthTotal
 	ch <- c.RandomIOsTotal
 	ch <- c.RandomBytesTotal
+	ch <- c.LastResetTime
+	ch <- c.StatisticsResets
 }
 
 func (c *DriveStatisticsCollector) Collect(ch chan<- prometheus.Metric) {
@@ -178,40 +248,76 @@ func (c *DriveStatisticsCollector) Collect(ch chan<- prometheus.Metric) {
 		drives[d.ID] = d
 	}
 
+	observations := newStatisticsObservations(c.target)
 	for _, s := range analysedDriveStatistics {
+		observed, fresh := observations.observe(s.ObservedTimeInMS)
+		if !fresh {
+			c.logger.Debug("Skipping stale drive statistics", "drive", s.ID, "observed", observed)
+			continue
+		}
 		drive, ok := drives[s.ID]
 		if !ok {
 			drive = Drive{Slot: s.ID}
 		}
-		ch <- prometheus.MustNewConstMetric(c.AverageReadOpSize, prometheus.GaugeValue, s.AverageReadOpSize, drive.TrayID, drive.Slot)
-		ch <- prometheus.MustNewConstMetric(c.AverageWriteOpSize, prometheus.GaugeValue, s.AverageWriteOpSize, drive.TrayID, drive.Slot)
-		ch <- prometheus.MustNewConstMetric(c.CombinedResponseTime, prometheus.GaugeValue, s.CombinedResponseTime, drive.TrayID, drive.Slot)
-		ch <- prometheus.MustNewConstMetric(c.ReadPhysicalIOps, prometheus.GaugeValue, s.ReadPhysicalIOps, drive.TrayID, drive.Slot)
-		ch <- prometheus.MustNewConstMetric(c.ReadResponseTime, prometheus.GaugeValue, s.ReadResponseTime, drive.TrayID, drive.Slot)
-		ch <- prometheus.MustNewConstMetric(c.WritePhysicalIOps, prometheus.GaugeValue, s.WritePhysicalIOps, drive.TrayID, drive.Slot)
-		ch <- prometheus.MustNewConstMetric(c.WriteResponseTime, prometheus.GaugeValue, s.WriteResponseTime, drive.TrayID, drive.Slot)
+		ch <- observations.metric(observed, prometheus.MustNewConstMetric(c.AverageReadOpSize, prometheus.GaugeValue, s.AverageReadOpSize, drive.TrayID, drive.Slot))
+		ch <- observations.metric(observed, prometheus.MustNewConstMetric(c.AverageWriteOpSize, prometheus.GaugeValue, s.AverageWriteOpSize, drive.TrayID, drive.Slot))
+		ch <- observations.metric(observed, prometheus.MustNewConstMetric(c.CombinedResponseTime, prometheus.GaugeValue, s.CombinedResponseTime, drive.TrayID, drive.Slot))
+		ch <- observations.metric(observed, prometheus.MustNewConstMetric(c.ReadPhysicalIOps, prometheus.GaugeValue, s.ReadPhysicalIOps, drive.TrayID, drive.Slot))
+		ch <- observations.metric(observed, prometheus.MustNewConstMetric(c.ReadResponseTime, prometheus.GaugeValue, s.ReadResponseTime, drive.TrayID, drive.Slot))
+		ch <- observations.metric(observed, prometheus.MustNewConstMetric(c.WritePhysicalIOps, prometheus.GaugeValue, s.WritePhysicalIOps, drive.TrayID, drive.Slot))
+		ch <- observations.metric(observed, prometheus.MustNewConstMetric(c.WriteResponseTime, prometheus.GaugeValue, s.WriteResponseTime, drive.TrayID, drive.Slot))
+		ch <- observations.metric(observed, prometheus.MustNewConstMetric(c.CombinedIOps, prometheus.GaugeValue, s.CombinedIOps, drive.TrayID, drive.Slot))
+		ch <- observations.metric(observed, prometheus.MustNewConstMetric(c.ReadThroughput, prometheus.GaugeValue, s.ReadThroughput, drive.TrayID, drive.Slot))
+		ch <- observations.metric(observed, prometheus.MustNewConstMetric(c.WriteThroughput, prometheus.GaugeValue, s.WriteThroughput, drive.TrayID, drive.Slot))
+		ch <- observations.metric(observed, prometheus.MustNewConstMetric(c.CombinedThroughput, prometheus.GaugeValue, s.CombinedThroughput, drive.TrayID, drive.Slot))
+		ch <- observations.metric(observed, prometheus.MustNewConstMetric(c.ReadResponseTimeStdDev, prometheus.GaugeValue, s.ReadResponseTimeStdDev, drive.TrayID, drive.Slot))
+		ch <- observations.metric(observed, prometheus.MustNewConstMetric(c.WriteResponseTimeStdDev, prometheus.GaugeValue, s.WriteResponseTimeStdDev, drive.TrayID, drive.Slot))
+		ch <- observations.metric(observed, prometheus.MustNewConstMetric(c.CombinedResponseTimeStdDev, prometheus.GaugeValue, s.CombinedResponseTimeStdDev, drive.TrayID, drive.Slot))
+		ch <- observations.metric(observed, prometheus.MustNewConstMetric(c.ReadTimeMax, prometheus.GaugeValue, s.ReadTimeMax, drive.TrayID, drive.Slot))
+		ch <- observations.metric(observed, prometheus.MustNewConstMetric(c.WriteTimeMax, prometheus.GaugeValue, s.WriteTimeMax, drive.TrayID, drive.Slot))
+		ch <- observations.metric(observed, prometheus.MustNewConstMetric(c.AverageQueueDepth, prometheus.GaugeValue, s.AverageQueueDepth, drive.TrayID, drive.Slot))
+		ch <- observations.metric(observed, prometheus.MustNewConstMetric(c.QueueDepthMax, prometheus.GaugeValue, s.QueueDepthMax, drive.TrayID, drive.Slot))
+		ch <- observations.metric(observed, prometheus.MustNewConstMetric(c.RandomIosPercent, prometheus.GaugeValue, s.RandomIosPercent, drive.TrayID, drive.Slot))
 	}
+	observations.collect(ch, "drive-statistics")
+	seen := make(map[string]bool)
 	for _, s := range driveStatistics {
 		drive, ok := drives[s.ID]
 		if !ok {
 			drive = Drive{Slot: s.ID}
 		}
-		ch <- prometheus.MustNewConstMetric(c.IdleTime, prometheus.CounterValue, s.IdleTime, drive.TrayID, drive.Slot)
-		ch <- prometheus.MustNewConstMetric(c.OtherOPs, prometheus.CounterValue, s.OtherOPs, drive.TrayID, drive.Slot)
-		ch <- prometheus.MustNewConstMetric(c.OtherTimeTotal, prometheus.CounterValue, s.OtherTimeTotal, drive.TrayID, drive.Slot)
-		ch <- prometheus.MustNewConstMetric(c.ReadBytes, prometheus.CounterValue, s.ReadBytes, drive.TrayID, drive.Slot)
-		ch <- prometheus.MustNewConstMetric(c.ReadOPs, prometheus.CounterValue, s.ReadOPs, drive.TrayID, drive.Slot)
-		ch <- prometheus.MustNewConstMetric(c.ReadTimeTotal, prometheus.CounterValue, s.ReadTimeTotal, drive.TrayID, drive.Slot)
-		ch <- prometheus.MustNewConstMetric(c.RecoveredErrors, prometheus.CounterValue, s.RecoveredErrors, drive.TrayID, drive.Slot)
-		ch <- prometheus.MustNewConstMetric(c.RetriedIOs, prometheus.CounterValue, s.RetriedIOs, drive.TrayID, drive.Slot)
-		ch <- prometheus.MustNewConstMetric(c.Timeouts, prometheus.CounterValue, s.Timeouts, drive.TrayID, drive.Slot)
-		ch <- prometheus.MustNewConstMetric(c.UnrecoveredErrors, prometheus.CounterValue, s.UnrecoveredErrors, drive.TrayID, drive.Slot)
-		ch <- prometheus.MustNewConstMetric(c.WriteBytes, prometheus.CounterValue, s.WriteBytes, drive.TrayID, drive.Slot)
-		ch <- prometheus.MustNewConstMetric(c.WriteOPs, prometheus.CounterValue, s.WriteOPs, drive.TrayID, drive.Slot)
-		ch <- prometheus.MustNewConstMetric(c.WriteTimeTotal, prometheus.CounterValue, s.WriteTimeTotal, drive.TrayID, drive.Slot)
-		ch <- prometheus.MustNewConstMetric(c.QueueDepthTotal, prometheus.CounterValue, s.QueueDepthTotal, drive.TrayID, drive.Slot)
-		ch <- prometheus.MustNewConstMetric(c.RandomIOsTotal, prometheus.CounterValue, s.RandomIOsTotal, drive.TrayID, drive.Slot)
-		ch <- prometheus.MustNewConstMetric(c.RandomBytesTotal, prometheus.CounterValue, s.RandomBytesTotal, drive.TrayID, drive.Slot)
+		created := parseTimeMS(s.LastResetTimeInMS)
+		if !created.IsZero() {
+			key := c.target.Name + "/drive/" + s.ID
+			seen[key] = true
+			resets := statisticsResets.observe(key, created)
+			ch <- prometheus.MustNewConstMetric(c.StatisticsResets, prometheus.CounterValue, resets, drive.TrayID, drive.Slot)
+			// OpenMetrics scrapes and OTLP pushes carry the reset time as created instead
+			if !c.target.CreatedTimestamps {
+				ch <- prometheus.MustNewConstMetric(c.LastResetTime, prometheus.GaugeValue, float64(created.Unix()), drive.TrayID, drive.Slot)
+			}
+		}
+		ch <- newCounter(c.target, c.IdleTime, s.IdleTime, created, drive.TrayID, drive.Slot)
+		ch <- newCounter(c.target, c.OtherOPs, s.OtherOPs, created, drive.TrayID, drive.Slot)
+		ch <- newCounter(c.target, c.OtherTimeTotal, s.OtherTimeTotal, created, drive.TrayID, drive.Slot)
+		ch <- newCounter(c.target, c.ReadBytes, s.ReadBytes, created, drive.TrayID, drive.Slot)
+		ch <- newCounter(c.target, c.ReadOPs, s.ReadOPs, created, drive.TrayID, drive.Slot)
+		ch <- newCounter(c.target, c.ReadTimeTotal, s.ReadTimeTotal, created, drive.TrayID, drive.Slot)
+		ch <- newCounter(c.target, c.RecoveredErrors, s.RecoveredErrors, created, drive.TrayID, drive.Slot)
+		ch <- newCounter(c.target, c.RetriedIOs, s.RetriedIOs, created, drive.TrayID, drive.Slot)
+		ch <- newCounter(c.target, c.Timeouts, s.Timeouts, created, drive.TrayID, drive.Slot)
+		ch <- newCounter(c.target, c.UnrecoveredErrors, s.UnrecoveredErrors, created, drive.TrayID, drive.Slot)
+		ch <- newCounter(c.target, c.WriteBytes, s.WriteBytes, created, drive.TrayID, drive.Slot)
+		ch <- newCounter(c.target, c.WriteOPs, s.WriteOPs, created, drive.TrayID, drive.Slot)
+		ch <- newCounter(c.target, c.WriteTimeTotal, s.WriteTimeTotal, created, drive.TrayID, drive.Slot)
+		ch <- newCounter(c.target, c.QueueDepthTotal, s.QueueDepthTotal, created, drive.TrayID, drive.Slot)
+		ch <- newCounter(c.target, c.RandomIOsTotal, s.RandomIOsTotal, created, drive.TrayID, drive.Slot)
+		ch <- newCounter(c.target, c.RandomBytesTotal, s.RandomBytesTotal, created, drive.TrayID, drive.Slot)
+	}
+
+	// A failed collection lists no objects, only a complete one shows which are gone
+	if err == nil {
+		statisticsResets.prune(c.target.Name+"/drive/", seen)
 	}
 
 	ch <- prometheus.MustNewConstMetric(collectError, prometheus.GaugeValue, float64(errorMetric), "drive-statistics")
@@ -269,6 +375,18 @@ func (c *DriveStatisticsCollector) collect() (DrivesInventory, []AnalysedDriveSt
 		s.CombinedResponseTime = s.CombinedResponseTime / 1000
 		s.ReadResponseTime = s.ReadResponseTime / 1000
 		s.WriteResponseTime = s.WriteResponseTime / 1000
+		s.ReadResponseTimeStdDev = s.ReadResponseTimeStdDev / 1000
+		s.WriteResponseTimeStdDev = s.WriteResponseTimeStdDev / 1000
+		s.CombinedResponseTimeStdDev = s.CombinedResponseTimeStdDev / 1000
+		// Convert microseconds to seconds
+		s.ReadTimeMax = s.ReadTimeMax / 1000000
+		s.WriteTimeMax = s.WriteTimeMax / 1000000
+		// Convert MiB/s to bytes/s
+		s.ReadThroughput = s.ReadThroughput * 1024 * 1024
+		s.WriteThroughput = s.WriteThroughput * 1024 * 1024
+		s.CombinedThroughput = s.CombinedThroughput * 1024 * 1024
+		// Convert from percent to ratio
+		s.RandomIosPercent = s.RandomIosPercent / 100
 	}
 	for i := range driveStatistics {
 		s := &driveStatistics[i]
@@ -31,6 +31,22 @@ func TestDriveStatisticsCollector(t *testing.T) {
 # TYPE eseries_drive_average_read_op_size_bytes gauge
 eseries_drive_average_read_op_size_bytes{slot="58",tray="0"} 39620.99569760295
 eseries_drive_average_read_op_size_bytes{slot="53",tray="0"} 21312.646464646463
+# HELP eseries_drive_average_queue_depth Drive statistic averageQueueDepth
+# TYPE eseries_drive_average_queue_depth gauge
+eseries_drive_average_queue_depth{slot="58",tray="0"} 3.037533512064343
+eseries_drive_average_queue_depth{slot="53",tray="0"} 2.1477449455676516
+# HELP eseries_drive_last_reset_timestamp_seconds Drive statistic lastResetTime, the Unix time the drive counters were last reset
+# TYPE eseries_drive_last_reset_timestamp_seconds gauge
+eseries_drive_last_reset_timestamp_seconds{slot="58",tray="0"} 1604971912
+eseries_drive_last_reset_timestamp_seconds{slot="53",tray="0"} 1604971912
+# HELP eseries_drive_queue_depth_max Drive statistic queueDepthMax
+# TYPE eseries_drive_queue_depth_max gauge
+eseries_drive_queue_depth_max{slot="58",tray="0"} 17
+eseries_drive_queue_depth_max{slot="53",tray="0"} 13
+# HELP eseries_drive_read_time_max_seconds Drive statistic readTimeMax
+# TYPE eseries_drive_read_time_max_seconds gauge
+eseries_drive_read_time_max_seconds{slot="58",tray="0"} 0.21875
+eseries_drive_read_time_max_seconds{slot="53",tray="0"} 0.111609
 # HELP eseries_exporter_collect_error Indicates if error has occurred during collection
 # TYPE eseries_exporter_collect_error gauge
 eseries_exporter_collect_error{collector="drive-statistics"} 0
@@ -58,11 +74,12 @@ eseries_exporter_collect_error{collector="drive-statistics"} 0
 	gatherers := setupGatherer(collector)
 	if val, err := testutil.GatherAndCount(gatherers); err != nil {
 		t.Errorf("Unexpected error: %v", err)
-	} else if val != 48 {
-		t.Errorf("Unexpected collection count %d, expected 48", val)
+	} else if val != 77 {
+		t.Errorf("Unexpected collection count %d, expected 77", val)
 	}
 	if err := testutil.GatherAndCompare(gatherers, strings.NewReader(expected),
-		"eseries_drive_average_read_op_size_bytes",
+		"eseries_drive_average_read_op_size_bytes", "eseries_drive_last_reset_timestamp_seconds",
+		"eseries_drive_average_queue_depth", "eseries_drive_queue_depth_max", "eseries_drive_read_time_max_seconds",
 		"eseries_exporter_collect_error"); err != nil {
 		t.Errorf("unexpected collecting result:\n%s", err)
 	}
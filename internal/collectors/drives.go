@@ -4,7 +4,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"log/slog"
-	"strconv"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -12,33 +11,19 @@ import (
 	"github.com/sckyzo/eseries_exporter/internal/config"
 )
 
-var (
-	driveStatuses = []string{
-		"optimal",
-		"failed",
-		"replaced",
-		"bypassed",
-		"unresponsive",
-		"removed",
-		"incompatible",
-		"dataRelocation",
-		"preFailCopy",
-		"preFailCopyPending",
-		"__UNDEFINED",
-	}
-)
-
 type DrivesInventory struct {
 	Drives []Drive `json:"drives"`
 	Trays  []Tray  `json:"trays"`
 }
 
 type Drive struct {
-	ID               string                `json:"id"`
-	Status           string                `json:"status"`
-	PhysicalLocation DrivePhysicalLocation `json:"physicalLocation"`
-	TrayID           string
-	Slot             string
+	ID                    string                `json:"id"`
+	Status                string                `json:"status"`
+	PhysicalLocation      DrivePhysicalLocation `json:"physicalLocation"`
+	CurrentVolumeGroupRef string                `json:"currentVolumeGroupRef"`
+	DriveMediaType        string                `json:"driveMediaType"`
+	TrayID                string
+	Slot                  string
 }
 
 type DrivePhysicalLocation struct {
@@ -47,7 +32,7 @@ type DrivePhysicalLocation struct {
 }
 
 type DrivesCollector struct {
-	Status *prometheus.Desc
+	status *componentStatusMetric
 	target config.Target
 	logger *slog.Logger
 }
@@ -58,74 +43,63 @@ func init() {
 
 func NewDrivesExporter(target config.Target, logger *slog.Logger) Collector {
 	return &DrivesCollector{
-		Status: prometheus.NewDesc(prometheus.BuildFQName(namespace, "drive", "status"),
-			"Drive status", []string{"tray", "slot", "status"}, nil),
+		status: newComponentStatusMetric(driveComponent, target),
 		target: target,
 		logger: logger,
 	}
 }
 
 func (c *DrivesCollector) Describe(ch chan<- *prometheus.Desc) {
-	ch <- c.Status
+	ch <- c.status.desc
 }
 
 func (c *DrivesCollector) Collect(ch chan<- prometheus.Metric) {
 	c.logger.Debug("Collecting drives metrics")
 	collectTime := time.Now()
 	var errorMetric int
-	metrics, err := c.collect()
+	drives, trays, err := c.collect()
 	if err != nil {
 		c.logger.Error("Collection failed", "error", err)
 		errorMetric = 1
 	}
 
-	trays := make(map[string]int)
-	for _, t := range metrics.Trays {
-		trays[t.TrayRef] = t.ID
-	}
 	var ids []string
-	for _, d := range metrics.Drives {
-		if trayId, ok := trays[d.PhysicalLocation.TrayRef]; ok {
-			d.TrayID = strconv.Itoa(trayId)
-		}
-		d.Slot = strconv.Itoa(d.PhysicalLocation.Slot)
-		id := fmt.Sprintf("%s-%s", d.TrayID, d.Slot)
+	for _, d := range drives {
+		tray, slot := trayLabel.Value(d, trays), slotLabel.Value(d, trays)
+		id := fmt.Sprintf("%s-%s", tray, slot)
 		if sliceContains(ids, id) {
-			c.logger.Error("Duplicate drive entry detected, skipping", "tray", d.TrayID, "slot", d.Slot, "status", d.Status)
+			c.logger.Error("Duplicate drive entry detected, skipping", "tray", tray, "slot", slot, "status", d.Status)
 			errorMetric = 1
 			continue
 		}
 		ids = append(ids, id)
-		for _, driveStatus := range driveStatuses {
-			var value float64
-			if driveStatus == d.Status {
-				value = 1
-			}
-			ch <- prometheus.MustNewConstMetric(c.Status, prometheus.GaugeValue, value, d.TrayID, d.Slot, driveStatus)
-		}
-		var unknown float64
-		if !sliceContains(driveStatuses, d.Status) {
-			unknown = 1
-		}
-		ch <- prometheus.MustNewConstMetric(c.Status, prometheus.GaugeValue, unknown, d.TrayID, d.Slot, "unknown")
+		c.status.collect(ch, d, trays)
 	}
 
 	ch <- prometheus.MustNewConstMetric(collectError, prometheus.GaugeValue, float64(errorMetric), "drives")
 	ch <- prometheus.MustNewConstMetric(collectDuration, prometheus.GaugeValue, time.Since(collectTime).Seconds(), "drives")
 }
 
-func (c *DrivesCollector) collect() (DrivesInventory, error) {
-	var metrics DrivesInventory
+func (c *DrivesCollector) collect() ([]InventoryComponent, map[string]string, error) {
+	var inventory map[string]json.RawMessage
+	var trays []Tray
+	var drives []InventoryComponent
 	body, err := getRequest(c.target, fmt.Sprintf("/devmgr/v2/storage-systems/%s/hardware-inventory", c.target.Name), c.logger)
 	if err != nil {
-		return metrics, err
+		return nil, nil, err
 	}
-	err = json.Unmarshal(body, &metrics)
+	err = json.Unmarshal(body, &inventory)
 	if err != nil {
-		return metrics, err
+		return nil, nil, err
+	}
+	if err := decodeInventory(inventory, "trays", &trays); err != nil {
+		return nil, nil, err
+	}
+	if err := decodeInventory(inventory, c.status.component.Path, &drives); err != nil {
+		return nil, nil, err
 	}
-	if len(metrics.Drives) == 0 {
-		return metrics, fmt.Errorf("No drives returned")
+	if len(drives) == 0 {
+		return nil, nil, fmt.Errorf("No drives returned")
 	}
-	return metrics, nil
+	return drives, trayIDs(trays), nil
 }
@@ -0,0 +1,150 @@
+package collector
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/sckyzo/eseries_exporter/internal/config"
+)
+
+type EnvironmentalInventory struct {
+	Trays         []Tray               `json:"trays"`
+	Fans          []EnvironmentFan     `json:"fans"`
+	PowerSupplies []InventoryComponent `json:"powerSupplies"`
+}
+
+type EnvironmentFan struct {
+	InventoryComponent
+	// FanSpeed is only reported by firmware with fan telemetry
+	FanSpeed *float64 `json:"fanSpeed"`
+}
+
+type EnergyStarResponse struct {
+	EnergyStarData EnergyStarData `json:"energyStarData"`
+}
+
+type EnergyStarData struct {
+	TotalPower *float64         `json:"totalPower"`
+	TrayPower  []TrayPowerInput `json:"trayPower"`
+}
+
+type TrayPowerInput struct {
+	TrayID                int       `json:"trayID"`
+	NumberOfPowerSupplies int       `json:"numberOfPowerSupplies"`
+	InputPower            []float64 `json:"inputPower"`
+}
+
+type EnvironmentalCollector struct {
+	FanSpeed           *prometheus.Desc
+	PowerSupplyInput   *prometheus.Desc
+	TrayPower          *prometheus.Desc
+	StorageSystemPower *prometheus.Desc
+	target             config.Target
+	logger             *slog.Logger
+}
+
+func init() {
+	registerCollector("environmental", false, NewEnvironmentalExporter)
+}
+
+func NewEnvironmentalExporter(target config.Target, logger *slog.Logger) Collector {
+	return &EnvironmentalCollector{
+		FanSpeed: prometheus.NewDesc(prometheus.BuildFQName(namespace, "fan", "speed_rpm"),
+			"Fan speed in revolutions per minute", []string{"tray", "slot"}, nil),
+		PowerSupplyInput: prometheus.NewDesc(prometheus.BuildFQName(namespace, "power_supply", "input_present"),
+			"Whether the power supply receives input power (1) or reports noinput (0)", []string{"tray", "slot"}, nil),
+		TrayPower: prometheus.NewDesc(prometheus.BuildFQName(namespace, "tray", "power_watts"),
+			"Input power drawn by all power supplies of the tray", []string{"tray"}, nil),
+		StorageSystemPower: prometheus.NewDesc(prometheus.BuildFQName(namespace, "storage_system", "power_watts"),
+			"Input power drawn by the storage system", nil, nil),
+		target: target,
+		logger: logger,
+	}
+}
+
+func (c *EnvironmentalCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.FanSpeed
+	ch <- c.PowerSupplyInput
+	ch <- c.TrayPower
+	ch <- c.StorageSystemPower
+}
+
+func (c *EnvironmentalCollector) Collect(ch chan<- prometheus.Metric) {
+	c.logger.Debug("Collecting environmental metrics")
+	collectTime := time.Now()
+	var errorMetric int
+	inventory, energyStar, err := c.collect()
+	if err != nil {
+		c.logger.Error("Collection failed", "error", err)
+		errorMetric = 1
+	}
+
+	trays := trayIDs(inventory.Trays)
+	for _, f := range inventory.Fans {
+		if f.FanSpeed == nil {
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(c.FanSpeed, prometheus.GaugeValue, *f.FanSpeed,
+			trayLabel.Value(f.InventoryComponent, trays), slotLabel.Value(f.InventoryComponent, trays))
+	}
+	// The inventory only reports input loss through the supply status
+	for _, p := range inventory.PowerSupplies {
+		if p.Status == "removed" {
+			continue
+		}
+		var inputPresent float64
+		if p.Status != "noinput" {
+			inputPresent = 1
+		}
+		ch <- prometheus.MustNewConstMetric(c.PowerSupplyInput, prometheus.GaugeValue, inputPresent,
+			trayLabel.Value(p, trays), slotLabel.Value(p, trays))
+	}
+
+	if energyStar != nil {
+		for _, t := range energyStar.TrayPower {
+			tray := strconv.Itoa(t.TrayID)
+			// Input power entries are not tied to a physical power supply, only
+			// their sum per tray is exported
+			var trayPower float64
+			for _, power := range t.InputPower {
+				trayPower += power
+			}
+			ch <- prometheus.MustNewConstMetric(c.TrayPower, prometheus.GaugeValue, trayPower, tray)
+		}
+		if energyStar.TotalPower != nil {
+			ch <- prometheus.MustNewConstMetric(c.StorageSystemPower, prometheus.GaugeValue, *energyStar.TotalPower)
+		}
+	}
+
+	ch <- prometheus.MustNewConstMetric(collectError, prometheus.GaugeValue, float64(errorMetric), "environmental")
+	ch <- prometheus.MustNewConstMetric(collectDuration, prometheus.GaugeValue, time.Since(collectTime).Seconds(), "environmental")
+}
+
+func (c *EnvironmentalCollector) collect() (EnvironmentalInventory, *EnergyStarData, error) {
+	var inventory EnvironmentalInventory
+	body, err := getRequest(c.target, fmt.Sprintf("/devmgr/v2/storage-systems/%s/hardware-inventory", c.target.Name), c.logger)
+	if err != nil {
+		return inventory, nil, err
+	}
+	err = json.Unmarshal(body, &inventory)
+	if err != nil {
+		return inventory, nil, err
+	}
+	// Power readings are not available on every firmware, their absence is not an error
+	body, err = postRequest(c.target, fmt.Sprintf("/devmgr/v2/storage-systems/%s/symbol/getEnergyStarData", c.target.Name), c.logger)
+	if isNotFound(err) {
+		return inventory, nil, nil
+	} else if err != nil {
+		return inventory, nil, err
+	}
+	var energyStar EnergyStarResponse
+	if err := json.Unmarshal(body, &energyStar); err != nil {
+		return inventory, nil, fmt.Errorf("failed to unmarshal energy star data: %w", err)
+	}
+	return inventory, &energyStar.EnergyStarData, nil
+}
@@ -0,0 +1,150 @@
+package collector
+
+import (
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/sckyzo/eseries_exporter/internal/config"
+)
+
+func TestEnvironmentalCollector(t *testing.T) {
+	inventoryData, err := os.ReadFile("testdata/environmental-inventory.json")
+	if err != nil {
+		t.Fatalf("Error loading fixture data: %s", err.Error())
+	}
+	energyStarData, err := os.ReadFile("testdata/energy-star.json")
+	if err != nil {
+		t.Fatalf("Error loading fixture data: %s", err.Error())
+	}
+	expected := `# HELP eseries_fan_speed_rpm Fan speed in revolutions per minute
+# TYPE eseries_fan_speed_rpm gauge
+eseries_fan_speed_rpm{slot="1",tray="99"} 5280
+# HELP eseries_power_supply_input_present Whether the power supply receives input power (1) or reports noinput (0)
+# TYPE eseries_power_supply_input_present gauge
+eseries_power_supply_input_present{slot="1",tray="99"} 1
+eseries_power_supply_input_present{slot="2",tray="99"} 0
+# HELP eseries_storage_system_power_watts Input power drawn by the storage system
+# TYPE eseries_storage_system_power_watts gauge
+eseries_storage_system_power_watts 1012
+# HELP eseries_tray_power_watts Input power drawn by all power supplies of the tray
+# TYPE eseries_tray_power_watts gauge
+eseries_tray_power_watts{tray="0"} 514
+eseries_tray_power_watts{tray="99"} 498
+# HELP eseries_exporter_collect_error Indicates if error has occurred during collection
+# TYPE eseries_exporter_collect_error gauge
+eseries_exporter_collect_error{collector="environmental"} 0
+`
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if strings.HasSuffix(req.URL.Path, "getEnergyStarData") {
+			_, _ = rw.Write(energyStarData)
+		} else {
+			_, _ = rw.Write(inventoryData)
+		}
+	}))
+	defer server.Close()
+	baseURL, _ := url.Parse(server.URL)
+	target := config.Target{
+		Name:       "test",
+		User:       "test",
+		Password:   "test",
+		BaseURL:    baseURL,
+		HttpClient: &http.Client{},
+	}
+	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+	collector := NewEnvironmentalExporter(target, logger)
+	gatherers := setupGatherer(collector)
+	if val, err := testutil.GatherAndCount(gatherers); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	} else if val != 8 {
+		t.Errorf("Unexpected collection count %d, expected 8", val)
+	}
+	if err := testutil.GatherAndCompare(gatherers, strings.NewReader(expected),
+		"eseries_fan_speed_rpm", "eseries_power_supply_input_present",
+		"eseries_storage_system_power_watts", "eseries_tray_power_watts", "eseries_exporter_collect_error"); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+}
+
+func TestEnvironmentalCollectorUnsupported(t *testing.T) {
+	fixtureData, err := os.ReadFile("testdata/hardware-inventory.json")
+	if err != nil {
+		t.Fatalf("Error loading fixture data: %s", err.Error())
+	}
+	expected := `# HELP eseries_exporter_collect_error Indicates if error has occurred during collection
+# TYPE eseries_exporter_collect_error gauge
+eseries_exporter_collect_error{collector="environmental"} 0
+`
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if strings.HasSuffix(req.URL.Path, "getEnergyStarData") {
+			http.Error(rw, "error", http.StatusNotFound)
+		} else {
+			_, _ = rw.Write(fixtureData)
+		}
+	}))
+	defer server.Close()
+	baseURL, _ := url.Parse(server.URL)
+	target := config.Target{
+		Name:       "test",
+		User:       "test",
+		Password:   "test",
+		BaseURL:    baseURL,
+		HttpClient: &http.Client{},
+	}
+	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+	collector := NewEnvironmentalExporter(target, logger)
+	gatherers := setupGatherer(collector)
+	if val, err := testutil.GatherAndCount(gatherers); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	} else if val != 4 {
+		t.Errorf("Unexpected collection count %d, expected 4", val)
+	}
+	if err := testutil.GatherAndCompare(gatherers, strings.NewReader(expected),
+		"eseries_fan_speed_rpm", "eseries_tray_power_watts", "eseries_exporter_collect_error"); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+}
+
+func TestEnvironmentalCollectorEnergyStarError(t *testing.T) {
+	fixtureData, err := os.ReadFile("testdata/environmental-inventory.json")
+	if err != nil {
+		t.Fatalf("Error loading fixture data: %s", err.Error())
+	}
+	expected := `# HELP eseries_fan_speed_rpm Fan speed in revolutions per minute
+# TYPE eseries_fan_speed_rpm gauge
+eseries_fan_speed_rpm{slot="1",tray="99"} 5280
+# HELP eseries_exporter_collect_error Indicates if error has occurred during collection
+# TYPE eseries_exporter_collect_error gauge
+eseries_exporter_collect_error{collector="environmental"} 1
+`
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if strings.HasSuffix(req.URL.Path, "getEnergyStarData") {
+			http.Error(rw, "error", http.StatusInternalServerError)
+		} else {
+			_, _ = rw.Write(fixtureData)
+		}
+	}))
+	defer server.Close()
+	baseURL, _ := url.Parse(server.URL)
+	target := config.Target{
+		Name:       "test",
+		User:       "test",
+		Password:   "test",
+		BaseURL:    baseURL,
+		HttpClient: &http.Client{},
+	}
+	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+	collector := NewEnvironmentalExporter(target, logger)
+	gatherers := setupGatherer(collector)
+	if err := testutil.GatherAndCompare(gatherers, strings.NewReader(expected),
+		"eseries_fan_speed_rpm", "eseries_tray_power_watts", "eseries_storage_system_power_watts",
+		"eseries_exporter_collect_error"); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+}
@@ -0,0 +1,174 @@
+package collector
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/sckyzo/eseries_exporter/internal/config"
+)
+
+type FlashCache struct {
+	ID             string `json:"id"`
+	FlashCacheBase struct {
+		Label      string `json:"label"`
+		Status     string `json:"status"`
+		ConfigType string `json:"configType"`
+	} `json:"flashCacheBase"`
+	DriveRefs     []string `json:"driveRefs"`
+	CachedVolumes []string `json:"cachedVolumes"`
+	Capacity      string   `json:"capacity"`
+	UsedCapacity  string   `json:"usedCapacity"`
+}
+
+type FlashCacheStatistics struct {
+	Reads               float64 `json:"reads"`
+	FullCacheHits       float64 `json:"fullCacheHits"`
+	PartialCacheHits    float64 `json:"partialCacheHits"`
+	CompleteCacheMiss   float64 `json:"completeCacheMiss"`
+	PopulateOnReads     float64 `json:"populateOnReads"`
+	PopulateOnWrites    float64 `json:"populateOnWrites"`
+	Invalidates         float64 `json:"invalidates"`
+	Recycles            float64 `json:"recycles"`
+	PopulatedCleanBytes float64 `json:"populatedCleanBytes"`
+	PopulatedDirtyBytes float64 `json:"populatedDirtyBytes"`
+}
+
+type FlashCacheCollector struct {
+	Status        *prometheus.Desc
+	Capacity      *prometheus.Desc
+	Used          *prometheus.Desc
+	Drives        *prometheus.Desc
+	CachedVolumes *prometheus.Desc
+	Reads         *prometheus.Desc
+	ReadHits      *prometheus.Desc
+	ReadMisses    *prometheus.Desc
+	Populates     *prometheus.Desc
+	Invalidates   *prometheus.Desc
+	Recycles      *prometheus.Desc
+	Populated     *prometheus.Desc
+	target        config.Target
+	logger        *slog.Logger
+}
+
+func init() {
+	registerCollector("flash-cache", false, NewFlashCacheExporter)
+}
+
+func NewFlashCacheExporter(target config.Target, logger *slog.Logger) Collector {
+	labels := []string{"cache"}
+	return &FlashCacheCollector{
+		Status: prometheus.NewDesc(prometheus.BuildFQName(namespace, "flash_cache", "status"),
+			"Status of the flash cache (1 for optimal, 0 otherwise)", []string{"cache", "config_type", "status"}, nil),
+		Capacity: prometheus.NewDesc(prometheus.BuildFQName(namespace, "flash_cache", "capacity_bytes"),
+			"Configured capacity of the flash cache in bytes", labels, nil),
+		Used: prometheus.NewDesc(prometheus.BuildFQName(namespace, "flash_cache", "used_bytes"),
+			"Used capacity of the flash cache in bytes", labels, nil),
+		Drives: prometheus.NewDesc(prometheus.BuildFQName(namespace, "flash_cache", "drives"),
+			"Number of member drives of the flash cache", labels, nil),
+		CachedVolumes: prometheus.NewDesc(prometheus.BuildFQName(namespace, "flash_cache", "cached_volumes"),
+			"Number of volumes using the flash cache", labels, nil),
+		Reads: prometheus.NewDesc(prometheus.BuildFQName(namespace, "flash_cache", "reads_total"),
+			"Read operations to volumes using the flash cache", labels, nil),
+		ReadHits: prometheus.NewDesc(prometheus.BuildFQName(namespace, "flash_cache", "read_hits_total"),
+			"Read operations served fully or partially from the flash cache", append(labels, "type"), nil),
+		ReadMisses: prometheus.NewDesc(prometheus.BuildFQName(namespace, "flash_cache", "read_misses_total"),
+			"Read operations with no data in the flash cache", labels, nil),
+		Populates: prometheus.NewDesc(prometheus.BuildFQName(namespace, "flash_cache", "populates_total"),
+			"Operations that populated the flash cache, by read or write", append(labels, "source"), nil),
+		Invalidates: prometheus.NewDesc(prometheus.BuildFQName(namespace, "flash_cache", "invalidates_total"),
+			"Flash cache blocks invalidated", labels, nil),
+		Recycles: prometheus.NewDesc(prometheus.BuildFQName(namespace, "flash_cache", "recycles_total"),
+			"Flash cache blocks recycled for new data", labels, nil),
+		Populated: prometheus.NewDesc(prometheus.BuildFQName(namespace, "flash_cache", "populated_bytes"),
+			"Flash cache capacity holding clean or dirty data in bytes", append(labels, "state"), nil),
+		target: target,
+		logger: logger,
+	}
+}
+
+func (c *FlashCacheCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.Status
+	ch <- c.Capacity
+	ch <- c.Used
+	ch <- c.Drives
+	ch <- c.CachedVolumes
+	ch <- c.Reads
+	ch <- c.ReadHits
+	ch <- c.ReadMisses
+	ch <- c.Populates
+	ch <- c.Invalidates
+	ch <- c.Recycles
+	ch <- c.Populated
+}
+
+func (c *FlashCacheCollector) Collect(ch chan<- prometheus.Metric) {
+	c.logger.Debug("Collecting flash cache metrics")
+	collectTime := time.Now()
+	var errorMetric int
+	cache, stats, err := c.collect()
+	if err != nil {
+		c.logger.Error("Collection failed", "error", err)
+		errorMetric = 1
+	}
+
+	if cache != nil {
+		label := cache.FlashCacheBase.Label
+		statusValue := 0.0
+		if cache.FlashCacheBase.Status == "optimal" {
+			statusValue = 1.0
+		}
+		ch <- prometheus.MustNewConstMetric(c.Status, prometheus.GaugeValue, statusValue,
+			label, cache.FlashCacheBase.ConfigType, cache.FlashCacheBase.Status)
+		capacity, _ := strconv.ParseFloat(cache.Capacity, 64)
+		ch <- prometheus.MustNewConstMetric(c.Capacity, prometheus.GaugeValue, capacity, label)
+		used, _ := strconv.ParseFloat(cache.UsedCapacity, 64)
+		ch <- prometheus.MustNewConstMetric(c.Used, prometheus.GaugeValue, used, label)
+		ch <- prometheus.MustNewConstMetric(c.Drives, prometheus.GaugeValue, float64(len(cache.DriveRefs)), label)
+		ch <- prometheus.MustNewConstMetric(c.CachedVolumes, prometheus.GaugeValue, float64(len(cache.CachedVolumes)), label)
+
+		if stats != nil {
+			ch <- prometheus.MustNewConstMetric(c.Reads, prometheus.CounterValue, stats.Reads, label)
+			ch <- prometheus.MustNewConstMetric(c.ReadHits, prometheus.CounterValue, stats.FullCacheHits, label, "full")
+			ch <- prometheus.MustNewConstMetric(c.ReadHits, prometheus.CounterValue, stats.PartialCacheHits, label, "partial")
+			ch <- prometheus.MustNewConstMetric(c.ReadMisses, prometheus.CounterValue, stats.CompleteCacheMiss, label)
+			ch <- prometheus.MustNewConstMetric(c.Populates, prometheus.CounterValue, stats.PopulateOnReads, label, "read")
+			ch <- prometheus.MustNewConstMetric(c.Populates, prometheus.CounterValue, stats.PopulateOnWrites, label, "write")
+			ch <- prometheus.MustNewConstMetric(c.Invalidates, prometheus.CounterValue, stats.Invalidates, label)
+			ch <- prometheus.MustNewConstMetric(c.Recycles, prometheus.CounterValue, stats.Recycles, label)
+			ch <- prometheus.MustNewConstMetric(c.Populated, prometheus.GaugeValue, stats.PopulatedCleanBytes, label, "clean")
+			ch <- prometheus.MustNewConstMetric(c.Populated, prometheus.GaugeValue, stats.PopulatedDirtyBytes, label, "dirty")
+		}
+	}
+
+	ch <- prometheus.MustNewConstMetric(collectError, prometheus.GaugeValue, float64(errorMetric), "flash-cache")
+	ch <- prometheus.MustNewConstMetric(collectDuration, prometheus.GaugeValue, time.Since(collectTime).Seconds(), "flash-cache")
+}
+
+func (c *FlashCacheCollector) collect() (*FlashCache, *FlashCacheStatistics, error) {
+	body, err := getOptionalRequest(c.target, fmt.Sprintf("/devmgr/v2/storage-systems/%s/flash-cache", c.target.Name), c.logger)
+	// Arrays without SSD cache answer with 404
+	if isNotFound(err) {
+		c.logger.Debug("No flash cache configured")
+		return nil, nil, nil
+	} else if err != nil {
+		return nil, nil, err
+	}
+	var cache FlashCache
+	if err := json.Unmarshal(body, &cache); err != nil {
+		return nil, nil, fmt.Errorf("failed to unmarshal flash cache: %w", err)
+	}
+	body, err = getRequest(c.target, fmt.Sprintf("/devmgr/v2/storage-systems/%s/flash-cache/statistics", c.target.Name), c.logger)
+	if err != nil {
+		return &cache, nil, err
+	}
+	var stats FlashCacheStatistics
+	if err := json.Unmarshal(body, &stats); err != nil {
+		return &cache, nil, fmt.Errorf("failed to unmarshal flash cache statistics: %w", err)
+	}
+	return &cache, &stats, nil
+}
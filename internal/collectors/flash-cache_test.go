@@ -0,0 +1,153 @@
+package collector
+
+import (
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/sckyzo/eseries_exporter/internal/config"
+)
+
+func TestFlashCacheCollector(t *testing.T) {
+	fixtures := make(map[string][]byte)
+	for path, file := range map[string]string{
+		"flash-cache":            "testdata/flash-cache.json",
+		"flash-cache/statistics": "testdata/flash-cache-statistics.json",
+	} {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatalf("Error loading fixture data: %s", err.Error())
+		}
+		fixtures[path] = data
+	}
+	expected := `# HELP eseries_flash_cache_status Status of the flash cache (1 for optimal, 0 otherwise)
+# TYPE eseries_flash_cache_status gauge
+eseries_flash_cache_status{cache="SSD_Cache_1",config_type="database",status="optimal"} 1
+# HELP eseries_flash_cache_capacity_bytes Configured capacity of the flash cache in bytes
+# TYPE eseries_flash_cache_capacity_bytes gauge
+eseries_flash_cache_capacity_bytes{cache="SSD_Cache_1"} 8e+11
+# HELP eseries_flash_cache_drives Number of member drives of the flash cache
+# TYPE eseries_flash_cache_drives gauge
+eseries_flash_cache_drives{cache="SSD_Cache_1"} 2
+# HELP eseries_flash_cache_cached_volumes Number of volumes using the flash cache
+# TYPE eseries_flash_cache_cached_volumes gauge
+eseries_flash_cache_cached_volumes{cache="SSD_Cache_1"} 3
+# HELP eseries_flash_cache_reads_total Read operations to volumes using the flash cache
+# TYPE eseries_flash_cache_reads_total counter
+eseries_flash_cache_reads_total{cache="SSD_Cache_1"} 100000
+# HELP eseries_flash_cache_read_hits_total Read operations served fully or partially from the flash cache
+# TYPE eseries_flash_cache_read_hits_total counter
+eseries_flash_cache_read_hits_total{cache="SSD_Cache_1",type="full"} 62000
+eseries_flash_cache_read_hits_total{cache="SSD_Cache_1",type="partial"} 8000
+# HELP eseries_flash_cache_read_misses_total Read operations with no data in the flash cache
+# TYPE eseries_flash_cache_read_misses_total counter
+eseries_flash_cache_read_misses_total{cache="SSD_Cache_1"} 30000
+# HELP eseries_flash_cache_populates_total Operations that populated the flash cache, by read or write
+# TYPE eseries_flash_cache_populates_total counter
+eseries_flash_cache_populates_total{cache="SSD_Cache_1",source="read"} 28000
+eseries_flash_cache_populates_total{cache="SSD_Cache_1",source="write"} 1200
+# HELP eseries_exporter_collect_error Indicates if error has occurred during collection
+# TYPE eseries_exporter_collect_error gauge
+eseries_exporter_collect_error{collector="flash-cache"} 0
+`
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		path := strings.TrimPrefix(req.URL.Path, "/devmgr/v2/storage-systems/test/")
+		data, ok := fixtures[path]
+		if !ok {
+			http.Error(rw, "not found", http.StatusNotFound)
+			return
+		}
+		_, _ = rw.Write(data)
+	}))
+	defer server.Close()
+	baseURL, _ := url.Parse(server.URL)
+	target := config.Target{
+		Name:       "test",
+		User:       "test",
+		Password:   "test",
+		BaseURL:    baseURL,
+		HttpClient: &http.Client{},
+	}
+	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+	collector := NewFlashCacheExporter(target, logger)
+	gatherers := setupGatherer(collector)
+	if val, err := testutil.GatherAndCount(gatherers); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	} else if val != 17 {
+		t.Errorf("Unexpected collection count %d, expected 17", val)
+	}
+	if err := testutil.GatherAndCompare(gatherers, strings.NewReader(expected),
+		"eseries_flash_cache_status", "eseries_flash_cache_capacity_bytes", "eseries_flash_cache_drives",
+		"eseries_flash_cache_cached_volumes", "eseries_flash_cache_reads_total", "eseries_flash_cache_read_hits_total",
+		"eseries_flash_cache_read_misses_total", "eseries_flash_cache_populates_total", "eseries_exporter_collect_error"); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+}
+
+func TestFlashCacheCollectorNotConfigured(t *testing.T) {
+	expected := `# HELP eseries_exporter_collect_error Indicates if error has occurred during collection
+# TYPE eseries_exporter_collect_error gauge
+eseries_exporter_collect_error{collector="flash-cache"} 0
+`
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		http.Error(rw, "not found", http.StatusNotFound)
+	}))
+	defer server.Close()
+	baseURL, _ := url.Parse(server.URL)
+	target := config.Target{
+		Name:       "test",
+		User:       "test",
+		Password:   "test",
+		BaseURL:    baseURL,
+		HttpClient: &http.Client{},
+	}
+	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+	collector := NewFlashCacheExporter(target, logger)
+	gatherers := setupGatherer(collector)
+	if val, err := testutil.GatherAndCount(gatherers); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	} else if val != 2 {
+		t.Errorf("Unexpected collection count %d, expected 2", val)
+	}
+	if err := testutil.GatherAndCompare(gatherers, strings.NewReader(expected),
+		"eseries_flash_cache_status", "eseries_exporter_collect_error"); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+}
+
+func TestFlashCacheCollectorError(t *testing.T) {
+	expected := `# HELP eseries_exporter_collect_error Indicates if error has occurred during collection
+# TYPE eseries_exporter_collect_error gauge
+eseries_exporter_collect_error{collector="flash-cache"} 1
+`
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		http.Error(rw, "error", http.StatusInternalServerError)
+	}))
+	defer server.Close()
+	baseURL, _ := url.Parse(server.URL)
+	target := config.Target{
+		Name:       "test",
+		User:       "test",
+		Password:   "test",
+		BaseURL:    baseURL,
+		HttpClient: &http.Client{},
+	}
+	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+	collector := NewFlashCacheExporter(target, logger)
+	gatherers := setupGatherer(collector)
+	if val, err := testutil.GatherAndCount(gatherers); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	} else if val != 2 {
+		t.Errorf("Unexpected collection count %d, expected 2", val)
+	}
+	if err := testutil.GatherAndCompare(gatherers, strings.NewReader(expected),
+		"eseries_flash_cache_status", "eseries_exporter_collect_error"); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+}
@@ -13,81 +13,50 @@ import (
 	"github.com/sckyzo/eseries_exporter/internal/config"
 )
 
-var (
-	batteryStatuses = []string{"optimal", "fullCharging", "nearExpiration", "failed", "removed", "notInConfig",
-		"configMismatch", "learning", "overtemp", "expired", "maintenanceCharging", "replacementRequired"}
-	fanStatuses             = []string{"optimal", "failed", "removed"}
-	powerSupplyStatuses     = []string{"optimal", "failed", "removed", "noinput"}
-	cacheMemoryDimmStatuses = []string{"optimal", "empty", "failed"}
-	thermalSensorStatuses   = []string{"optimal", "nominalTempExceed", "maxTempExceed", "removed"}
-)
-
-type HardwareInventory struct {
-	Trays            []Tray            `json:"trays"`
-	Batteries        []Battery         `json:"batteries"`
-	Fans             []Fan             `json:"fans"`
-	PowerSupplies    []PowerSupply     `json:"powerSupplies"`
-	CacheMemoryDimms []CacheMemoryDimm `json:"cacheMemoryDimms"`
-	ThermalSensors   []ThermalSensor   `json:"thermalSensors"`
-}
-
 type Battery struct {
-	ID               string `json:"id"`
-	TrayID           string
-	Slot             string
-	Status           string           `json:"status"`
-	PhysicalLocation PhysicalLocation `json:"physicalLocation"`
-}
-
-type Fan struct {
-	ID               string `json:"id"`
-	TrayID           string
-	Slot             string
-	Status           string           `json:"status"`
-	PhysicalLocation PhysicalLocation `json:"physicalLocation"`
+	InventoryComponent
+	BatteryAge           *float64         `json:"batteryAge"`
+	BatteryLifeRemaining *float64         `json:"batteryLifeRemaining"`
+	LearnCycleData       BatteryLearnData `json:"learnCycleData"`
+	SmartBatteryData     BatteryLearnData `json:"smartBatteryData"`
 }
 
-type PowerSupply struct {
-	ID               string `json:"id"`
-	TrayID           string
-	Slot             string
-	Status           string           `json:"status"`
-	PhysicalLocation PhysicalLocation `json:"physicalLocation"`
+type BatteryLearnData struct {
+	LastBatteryLearnCycle string `json:"lastBatteryLearnCycle"`
+	NextBatteryLearnCycle string `json:"nextBatteryLearnCycle"`
 }
 
-type CacheMemoryDimm struct {
-	TrayID           string
-	Slot             string
-	Status           string           `json:"status"`
-	PhysicalLocation PhysicalLocation `json:"physicalLocation"`
+type InventoryDrive struct {
+	InventoryComponent
+	DriveTemperature DriveTemperature `json:"driveTemperature"`
 }
 
-type ThermalSensor struct {
-	ID               string `json:"id"`
-	TrayID           string
-	Slot             string
-	Status           string           `json:"status"`
-	PhysicalLocation PhysicalLocation `json:"physicalLocation"`
+type DriveTemperature struct {
+	CurrentTemp float64 `json:"currentTemp"`
+	RefTemp     float64 `json:"refTemp"`
 }
 
-type PhysicalLocation struct {
-	Slot    int    `json:"slot"`
-	TrayRef string `json:"trayRef"`
+type EnclosureTemperatures struct {
+	ThermalSensorData []ThermalSensorReading `json:"thermalSensorData"`
 }
 
-type Tray struct {
-	TrayRef string `json:"trayRef"`
-	ID      int    `json:"trayId"`
+type ThermalSensorReading struct {
+	ThermalSensorRef string  `json:"thermalSensorRef"`
+	CurrentTemp      float64 `json:"currentTemp"`
 }
 
 type HardwareInventoryCollector struct {
-	BatteryStatus         *prometheus.Desc
-	FanStatus             *prometheus.Desc
-	PowerSupplyStatus     *prometheus.Desc
-	CacheMemoryDimmStatus *prometheus.Desc
-	ThermalSensorStatus   *prometheus.Desc
-	target                config.Target
-	logger                *slog.Logger
+	statuses                []*componentStatusMetric
+	BatteryAge              *prometheus.Desc
+	BatteryLifeRemaining    *prometheus.Desc
+	BatteryLastLearnCycle   *prometheus.Desc
+	BatteryNextLearnCycle   *prometheus.Desc
+	BatteryLearnCycleActive *prometheus.Desc
+	ThermalSensorTemp       *prometheus.Desc
+	DriveTemp               *prometheus.Desc
+	TrayDriveTempMax        *prometheus.Desc
+	target                  config.Target
+	logger                  *slog.Logger
 }
 
 func init() {
@@ -95,28 +64,46 @@ func init() {
 }
 
 func NewHardwareInventoryExporter(target config.Target, logger *slog.Logger) Collector {
+	statuses := make([]*componentStatusMetric, 0, len(hardwareInventoryComponents))
+	for _, component := range hardwareInventoryComponents {
+		statuses = append(statuses, newComponentStatusMetric(component, target))
+	}
+	labels := []string{"tray", "slot"}
 	return &HardwareInventoryCollector{
-		BatteryStatus: prometheus.NewDesc(prometheus.BuildFQName(namespace, "battery", "status"),
-			"Status of battery hardware device", []string{"tray", "slot", "status"}, nil),
-		FanStatus: prometheus.NewDesc(prometheus.BuildFQName(namespace, "fan", "status"),
-			"Status of fan hardware device", []string{"tray", "slot", "status"}, nil),
-		PowerSupplyStatus: prometheus.NewDesc(prometheus.BuildFQName(namespace, "power_supply", "status"),
-			"Status of power supply hardware device", []string{"tray", "slot", "status"}, nil),
-		CacheMemoryDimmStatus: prometheus.NewDesc(prometheus.BuildFQName(namespace, "cache_memory_dimm", "status"),
-			"Status of cache memory DIMM hardware device", []string{"tray", "slot", "status"}, nil),
-		ThermalSensorStatus: prometheus.NewDesc(prometheus.BuildFQName(namespace, "thermal_sensor", "status"),
-			"Status of thermal sensor hardware device", []string{"tray", "slot", "status"}, nil),
+		statuses: statuses,
+		BatteryAge: prometheus.NewDesc(prometheus.BuildFQName(namespace, "battery", "age_seconds"),
+			"Battery age reported by the controller", labels, nil),
+		BatteryLifeRemaining: prometheus.NewDesc(prometheus.BuildFQName(namespace, "battery", "life_remaining_seconds"),
+			"Time remaining until the battery should be replaced", labels, nil),
+		BatteryLastLearnCycle: prometheus.NewDesc(prometheus.BuildFQName(namespace, "battery", "last_learn_cycle_timestamp_seconds"),
+			"Time of the last battery learn cycle", labels, nil),
+		BatteryNextLearnCycle: prometheus.NewDesc(prometheus.BuildFQName(namespace, "battery", "next_learn_cycle_timestamp_seconds"),
+			"Time of the next scheduled battery learn cycle", labels, nil),
+		BatteryLearnCycleActive: prometheus.NewDesc(prometheus.BuildFQName(namespace, "battery", "learn_cycle_in_progress"),
+			"Whether a battery learn cycle is in progress (1) or not (0)", labels, nil),
+		ThermalSensorTemp: prometheus.NewDesc(prometheus.BuildFQName(namespace, "thermal_sensor", "temperature_celsius"),
+			"Temperature reported by the thermal sensor", []string{"tray", "slot", "sensor"}, nil),
+		DriveTemp: prometheus.NewDesc(prometheus.BuildFQName(namespace, "drive", "temperature_celsius"),
+			"Current drive temperature", labels, nil),
+		TrayDriveTempMax: prometheus.NewDesc(prometheus.BuildFQName(namespace, "tray", "drive_temperature_max_celsius"),
+			"Highest drive temperature in the tray", []string{"tray"}, nil),
 		target: target,
 		logger: logger,
 	}
 }
 
 func (c *HardwareInventoryCollector) Describe(ch chan<- *prometheus.Desc) {
-	ch <- c.BatteryStatus
-	ch <- c.FanStatus
-	ch <- c.PowerSupplyStatus
-	ch <- c.CacheMemoryDimmStatus
-	ch <- c.ThermalSensorStatus
+	for _, s := range c.statuses {
+		ch <- s.desc
+	}
+	ch <- c.BatteryAge
+	ch <- c.BatteryLifeRemaining
+	ch <- c.BatteryLastLearnCycle
+	ch <- c.BatteryNextLearnCycle
+	ch <- c.BatteryLearnCycleActive
+	ch <- c.ThermalSensorTemp
+	ch <- c.DriveTemp
+	ch <- c.TrayDriveTempMax
 }
 
 func (c *HardwareInventoryCollector) Collect(ch chan<- prometheus.Metric) {
@@ -129,107 +116,136 @@ func (c *HardwareInventoryCollector) Collect(ch chan<- prometheus.Metric) {
 		errorMetric = 1
 	}
 
-	trays := make(map[string]int)
-	for _, t := range inventory.Trays {
-		trays[t.TrayRef] = t.ID
-	}
-	for _, d := range inventory.Batteries {
-		if trayId, ok := trays[d.PhysicalLocation.TrayRef]; ok {
-			d.TrayID = strconv.Itoa(trayId)
+	if err == nil {
+		var trays []Tray
+		if err := decodeInventory(inventory, "trays", &trays); err != nil {
+			c.logger.Error("Collection failed", "error", err)
+			errorMetric = 1
 		}
-		d.Slot = strconv.Itoa(d.PhysicalLocation.Slot)
-		for _, s := range batteryStatuses {
-			var value float64
-			if strings.EqualFold(s, d.Status) {
-				value = 1
+		trayIDs := trayIDs(trays)
+		for _, s := range c.statuses {
+			var components []InventoryComponent
+			if err := decodeInventory(inventory, s.component.Path, &components); err != nil {
+				c.logger.Error("Collection failed", "component", s.component.Name, "error", err)
+				errorMetric = 1
+				continue
 			}
-			ch <- prometheus.MustNewConstMetric(c.BatteryStatus, prometheus.GaugeValue, value, d.TrayID, d.Slot, s)
-		}
-		var unknown float64
-		if !sliceContains(batteryStatuses, d.Status) {
-			unknown = 1
-		}
-		ch <- prometheus.MustNewConstMetric(c.BatteryStatus, prometheus.GaugeValue, unknown, d.TrayID, d.Slot, "unknown")
-	}
-	for _, d := range inventory.Fans {
-		if trayId, ok := trays[d.PhysicalLocation.TrayRef]; ok {
-			d.TrayID = strconv.Itoa(trayId)
-		}
-		d.Slot = strconv.Itoa(d.PhysicalLocation.Slot)
-		for _, s := range fanStatuses {
-			var value float64
-			if strings.EqualFold(s, d.Status) {
-				value = 1
+			for _, component := range components {
+				s.collect(ch, component, trayIDs)
 			}
-			ch <- prometheus.MustNewConstMetric(c.FanStatus, prometheus.GaugeValue, value, d.TrayID, d.Slot, s)
 		}
-		var unknown float64
-		if !sliceContains(fanStatuses, d.Status) {
-			unknown = 1
+		var batteries []Battery
+		if err := decodeInventory(inventory, batteryComponent.Path, &batteries); err != nil {
+			c.logger.Error("Collection failed", "component", batteryComponent.Name, "error", err)
+			errorMetric = 1
 		}
-		ch <- prometheus.MustNewConstMetric(c.FanStatus, prometheus.GaugeValue, unknown, d.TrayID, d.Slot, "unknown")
-	}
-	for _, d := range inventory.PowerSupplies {
-		if trayId, ok := trays[d.PhysicalLocation.TrayRef]; ok {
-			d.TrayID = strconv.Itoa(trayId)
+		for _, b := range batteries {
+			c.collectBattery(ch, b, trayIDs)
 		}
-		d.Slot = strconv.Itoa(d.PhysicalLocation.Slot)
-		for _, s := range powerSupplyStatuses {
-			var value float64
-			if strings.EqualFold(s, d.Status) {
-				value = 1
-			}
-			ch <- prometheus.MustNewConstMetric(c.PowerSupplyStatus, prometheus.GaugeValue, value, d.TrayID, d.Slot, s)
+		var thermalSensors []InventoryComponent
+		if err := decodeInventory(inventory, thermalSensorComponent.Path, &thermalSensors); err != nil {
+			c.logger.Error("Collection failed", "component", thermalSensorComponent.Name, "error", err)
+			errorMetric = 1
 		}
-		var unknown float64
-		if !sliceContains(powerSupplyStatuses, d.Status) {
-			unknown = 1
+		if err := c.collectThermalSensors(ch, thermalSensors, trayIDs); err != nil {
+			c.logger.Error("Collection failed", "component", thermalSensorComponent.Name, "error", err)
+			errorMetric = 1
 		}
-		ch <- prometheus.MustNewConstMetric(c.PowerSupplyStatus, prometheus.GaugeValue, unknown, d.TrayID, d.Slot, "unknown")
-	}
-	for _, d := range inventory.CacheMemoryDimms {
-		if trayId, ok := trays[d.PhysicalLocation.TrayRef]; ok {
-			d.TrayID = strconv.Itoa(trayId)
+		var drives []InventoryDrive
+		if err := decodeInventory(inventory, driveComponent.Path, &drives); err != nil {
+			c.logger.Error("Collection failed", "component", driveComponent.Name, "error", err)
+			errorMetric = 1
 		}
-		d.Slot = strconv.Itoa(d.PhysicalLocation.Slot)
-		for _, s := range cacheMemoryDimmStatuses {
-			var value float64
-			if strings.EqualFold(s, d.Status) {
-				value = 1
-			}
-			ch <- prometheus.MustNewConstMetric(c.CacheMemoryDimmStatus, prometheus.GaugeValue, value, d.TrayID, d.Slot, s)
-		}
-		var unknown float64
-		if !sliceContains(cacheMemoryDimmStatuses, d.Status) {
-			unknown = 1
+		c.collectDriveTemperatures(ch, drives, trayIDs)
+	}
+
+	ch <- prometheus.MustNewConstMetric(collectError, prometheus.GaugeValue, float64(errorMetric), "hardware-inventory")
+	ch <- prometheus.MustNewConstMetric(collectDuration, prometheus.GaugeValue, time.Since(collectTime).Seconds(), "hardware-inventory")
+}
+
+func (c *HardwareInventoryCollector) collectBattery(ch chan<- prometheus.Metric, b Battery, trays map[string]string) {
+	tray, slot := trayLabel.Value(b.InventoryComponent, trays), slotLabel.Value(b.InventoryComponent, trays)
+	// Age and remaining life are reported in days, -1 or missing when unknown
+	if b.BatteryAge != nil && *b.BatteryAge >= 0 {
+		ch <- prometheus.MustNewConstMetric(c.BatteryAge, prometheus.GaugeValue, *b.BatteryAge*86400, tray, slot)
+	}
+	if b.BatteryLifeRemaining != nil && *b.BatteryLifeRemaining >= 0 {
+		ch <- prometheus.MustNewConstMetric(c.BatteryLifeRemaining, prometheus.GaugeValue, *b.BatteryLifeRemaining*86400, tray, slot)
+	}
+	learnData := b.LearnCycleData
+	if learnData.NextBatteryLearnCycle == "" {
+		learnData = b.SmartBatteryData
+	}
+	if last, err := strconv.ParseFloat(learnData.LastBatteryLearnCycle, 64); err == nil && last > 0 {
+		ch <- prometheus.MustNewConstMetric(c.BatteryLastLearnCycle, prometheus.GaugeValue, last, tray, slot)
+	}
+	if next, err := strconv.ParseFloat(learnData.NextBatteryLearnCycle, 64); err == nil && next > 0 {
+		ch <- prometheus.MustNewConstMetric(c.BatteryNextLearnCycle, prometheus.GaugeValue, next, tray, slot)
+	}
+	var learning float64
+	if strings.EqualFold(b.Status, "learning") {
+		learning = 1
+	}
+	ch <- prometheus.MustNewConstMetric(c.BatteryLearnCycleActive, prometheus.GaugeValue, learning, tray, slot)
+}
+
+func (c *HardwareInventoryCollector) collectThermalSensors(ch chan<- prometheus.Metric, sensors []InventoryComponent, trays map[string]string) error {
+	if len(sensors) == 0 {
+		return nil
+	}
+	// Readings are not part of the inventory and not every firmware provides them
+	body, err := postRequest(c.target, fmt.Sprintf("/devmgr/v2/storage-systems/%s/symbol/getEnclosureTemperatures", c.target.Name), c.logger)
+	if isNotFound(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	var temperatures EnclosureTemperatures
+	if err := json.Unmarshal(body, &temperatures); err != nil {
+		return fmt.Errorf("failed to unmarshal enclosure temperatures: %w", err)
+	}
+	readings := make(map[string]float64)
+	for _, r := range temperatures.ThermalSensorData {
+		readings[r.ThermalSensorRef] = r.CurrentTemp
+	}
+	for _, s := range sensors {
+		temp, ok := readings[s.ID]
+		if !ok {
+			continue
 		}
-		ch <- prometheus.MustNewConstMetric(c.CacheMemoryDimmStatus, prometheus.GaugeValue, unknown, d.TrayID, d.Slot, "unknown")
+		ch <- prometheus.MustNewConstMetric(c.ThermalSensorTemp, prometheus.GaugeValue, temp,
+			trayLabel.Value(s, trays), slotLabel.Value(s, trays), s.PhysicalLocation.Label)
 	}
-	for _, d := range inventory.ThermalSensors {
-		if trayId, ok := trays[d.PhysicalLocation.TrayRef]; ok {
-			d.TrayID = strconv.Itoa(trayId)
+	return nil
+}
+
+func (c *HardwareInventoryCollector) collectDriveTemperatures(ch chan<- prometheus.Metric, drives []InventoryDrive, trays map[string]string) {
+	trayMax := make(map[string]float64)
+	var ids []string
+	for _, d := range drives {
+		// Drives that are removed or not reporting return 0
+		if d.DriveTemperature.CurrentTemp <= 0 {
+			continue
 		}
-		d.Slot = strconv.Itoa(d.PhysicalLocation.Slot)
-		for _, s := range thermalSensorStatuses {
-			var value float64
-			if strings.EqualFold(s, d.Status) {
-				value = 1
-			}
-			ch <- prometheus.MustNewConstMetric(c.ThermalSensorStatus, prometheus.GaugeValue, value, d.TrayID, d.Slot, s)
+		tray, slot := trayLabel.Value(d.InventoryComponent, trays), slotLabel.Value(d.InventoryComponent, trays)
+		// Duplicate entries are reported by the drives collector
+		id := fmt.Sprintf("%s-%s", tray, slot)
+		if sliceContains(ids, id) {
+			continue
 		}
-		var unknown float64
-		if !sliceContains(thermalSensorStatuses, d.Status) {
-			unknown = 1
+		ids = append(ids, id)
+		ch <- prometheus.MustNewConstMetric(c.DriveTemp, prometheus.GaugeValue, d.DriveTemperature.CurrentTemp, tray, slot)
+		if highest, ok := trayMax[tray]; !ok || d.DriveTemperature.CurrentTemp > highest {
+			trayMax[tray] = d.DriveTemperature.CurrentTemp
 		}
-		ch <- prometheus.MustNewConstMetric(c.ThermalSensorStatus, prometheus.GaugeValue, unknown, d.TrayID, d.Slot, "unknown")
 	}
-
-	ch <- prometheus.MustNewConstMetric(collectError, prometheus.GaugeValue, float64(errorMetric), "hardware-inventory")
-	ch <- prometheus.MustNewConstMetric(collectDuration, prometheus.GaugeValue, time.Since(collectTime).Seconds(), "hardware-inventory")
+	for tray, highest := range trayMax {
+		ch <- prometheus.MustNewConstMetric(c.TrayDriveTempMax, prometheus.GaugeValue, highest, tray)
+	}
 }
 
-func (c *HardwareInventoryCollector) collect() (HardwareInventory, error) {
-	var inventory HardwareInventory
+func (c *HardwareInventoryCollector) collect() (map[string]json.RawMessage, error) {
+	var inventory map[string]json.RawMessage
 	body, err := getRequest(c.target, fmt.Sprintf("/devmgr/v2/storage-systems/%s/hardware-inventory", c.target.Name), c.logger)
 	if err != nil {
 		return inventory, err
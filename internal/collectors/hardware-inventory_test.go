@@ -19,7 +19,23 @@ func TestHardwareInventoryCollector(t *testing.T) {
 	if err != nil {
 		t.Fatalf("Error loading fixture data: %s", err.Error())
 	}
-	expected := `# HELP eseries_battery_status Status of battery hardware device
+	expected := `# HELP eseries_battery_age_seconds Battery age reported by the controller
+# TYPE eseries_battery_age_seconds gauge
+eseries_battery_age_seconds{slot="1",tray="99"} 1.600128e+08
+eseries_battery_age_seconds{slot="2",tray="99"} 1.600128e+08
+# HELP eseries_battery_last_learn_cycle_timestamp_seconds Time of the last battery learn cycle
+# TYPE eseries_battery_last_learn_cycle_timestamp_seconds gauge
+eseries_battery_last_learn_cycle_timestamp_seconds{slot="1",tray="99"} 1.606348801e+09
+eseries_battery_last_learn_cycle_timestamp_seconds{slot="2",tray="99"} 1.606348801e+09
+# HELP eseries_battery_learn_cycle_in_progress Whether a battery learn cycle is in progress (1) or not (0)
+# TYPE eseries_battery_learn_cycle_in_progress gauge
+eseries_battery_learn_cycle_in_progress{slot="1",tray="99"} 0
+eseries_battery_learn_cycle_in_progress{slot="2",tray="99"} 0
+# HELP eseries_battery_next_learn_cycle_timestamp_seconds Time of the next scheduled battery learn cycle
+# TYPE eseries_battery_next_learn_cycle_timestamp_seconds gauge
+eseries_battery_next_learn_cycle_timestamp_seconds{slot="1",tray="99"} 1.6111872e+09
+eseries_battery_next_learn_cycle_timestamp_seconds{slot="2",tray="99"} 1.6111872e+09
+# HELP eseries_battery_status Status of battery hardware device
 # TYPE eseries_battery_status gauge
 eseries_battery_status{slot="1",status="configMismatch",tray="99"} 0
 eseries_battery_status{slot="1",status="expired",tray="99"} 0
@@ -112,17 +128,48 @@ eseries_exporter_collect_error{collector="hardware-inventory"} 0
 	gatherers := setupGatherer(collector)
 	if val, err := testutil.GatherAndCount(gatherers); err != nil {
 		t.Errorf("Unexpected error: %v", err)
-	} else if val != 64 {
-		t.Errorf("Unexpected collection count %d, expected 64", val)
+	} else if val != 72 {
+		t.Errorf("Unexpected collection count %d, expected 72", val)
 	}
 	if err := testutil.GatherAndCompare(gatherers, strings.NewReader(expected),
-		"eseries_battery_status", "eseries_fan_status",
+		"eseries_battery_age_seconds", "eseries_battery_life_remaining_seconds",
+		"eseries_battery_last_learn_cycle_timestamp_seconds", "eseries_battery_next_learn_cycle_timestamp_seconds",
+		"eseries_battery_learn_cycle_in_progress", "eseries_battery_status", "eseries_fan_status",
 		"eseries_power_supply_status", "eseries_cache_memory_dimm_status",
 		"eseries_thermal_sensor_status", "eseries_exporter_collect_error"); err != nil {
 		t.Errorf("unexpected collecting result:\n%s", err)
 	}
 }
 
+func TestHardwareInventoryCollectorBatteryMissingAge(t *testing.T) {
+	// Firmware omitting age and remaining life must not report a battery with no life left
+	expected := `# HELP eseries_battery_learn_cycle_in_progress Whether a battery learn cycle is in progress (1) or not (0)
+# TYPE eseries_battery_learn_cycle_in_progress gauge
+eseries_battery_learn_cycle_in_progress{slot="1",tray="99"} 0
+`
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = rw.Write([]byte(`{"trays":[{"trayRef":"0E00000000000000000000000000000000000000","trayId":99}],
+"batteries":[{"id":"battery1","status":"optimal","physicalLocation":{"slot":1,"trayRef":"0E00000000000000000000000000000000000000"}}]}`))
+	}))
+	defer server.Close()
+	baseURL, _ := url.Parse(server.URL)
+	target := config.Target{
+		Name:       "test",
+		User:       "test",
+		Password:   "test",
+		BaseURL:    baseURL,
+		HttpClient: &http.Client{},
+	}
+	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+	collector := NewHardwareInventoryExporter(target, logger)
+	gatherers := setupGatherer(collector)
+	if err := testutil.GatherAndCompare(gatherers, strings.NewReader(expected),
+		"eseries_battery_age_seconds", "eseries_battery_life_remaining_seconds",
+		"eseries_battery_learn_cycle_in_progress"); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+}
+
 func TestHardwareInventoryCollectorError(t *testing.T) {
 	expected := `# HELP eseries_exporter_collect_error Indicates if error has occurred during collection
 # TYPE eseries_exporter_collect_error gauge
@@ -153,3 +200,172 @@ eseries_exporter_collect_error{collector="hardware-inventory"} 1
 		t.Errorf("unexpected collecting result:\n%s", err)
 	}
 }
+
+func TestHardwareInventoryCollectorComponentStatuses(t *testing.T) {
+	fixtureData, err := os.ReadFile("testdata/hardware-inventory.json")
+	if err != nil {
+		t.Fatalf("Error loading fixture data: %s", err.Error())
+	}
+	expected := `# HELP eseries_thermal_sensor_status Status of thermal sensor hardware device
+# TYPE eseries_thermal_sensor_status gauge
+eseries_thermal_sensor_status{slot="1",status="foo",tray="99"} 0
+eseries_thermal_sensor_status{slot="1",status="maxTempExceed",tray="99"} 0
+eseries_thermal_sensor_status{slot="1",status="nominalTempExceed",tray="99"} 0
+eseries_thermal_sensor_status{slot="1",status="optimal",tray="99"} 1
+eseries_thermal_sensor_status{slot="1",status="removed",tray="99"} 0
+eseries_thermal_sensor_status{slot="1",status="unknown",tray="99"} 0
+eseries_thermal_sensor_status{slot="2",status="foo",tray="99"} 1
+eseries_thermal_sensor_status{slot="2",status="maxTempExceed",tray="99"} 0
+eseries_thermal_sensor_status{slot="2",status="nominalTempExceed",tray="99"} 0
+eseries_thermal_sensor_status{slot="2",status="optimal",tray="99"} 0
+eseries_thermal_sensor_status{slot="2",status="removed",tray="99"} 0
+eseries_thermal_sensor_status{slot="2",status="unknown",tray="99"} 0
+`
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = rw.Write(fixtureData)
+	}))
+	defer server.Close()
+	baseURL, _ := url.Parse(server.URL)
+	target := config.Target{
+		Name:              "test",
+		User:              "test",
+		Password:          "test",
+		BaseURL:           baseURL,
+		HttpClient:        &http.Client{},
+		ComponentStatuses: map[string][]string{"thermal_sensor": {"foo", "optimal"}},
+	}
+	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+	collector := NewHardwareInventoryExporter(target, logger)
+	gatherers := setupGatherer(collector)
+	if val, err := testutil.GatherAndCount(gatherers); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	} else if val != 74 {
+		t.Errorf("Unexpected collection count %d, expected 74", val)
+	}
+	if err := testutil.GatherAndCompare(gatherers, strings.NewReader(expected),
+		"eseries_thermal_sensor_status"); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+}
+
+func TestStatusComponentNames(t *testing.T) {
+	components := append([]StatusComponent{driveComponent}, hardwareInventoryComponents...)
+	if len(components) != len(config.ComponentNames) {
+		t.Errorf("Unexpected config component names %v for %d components", config.ComponentNames, len(components))
+	}
+	for _, c := range components {
+		if !sliceContains(config.ComponentNames, c.Name) {
+			t.Errorf("Component %s missing from config component names", c.Name)
+		}
+	}
+}
+
+func TestHardwareInventoryCollectorTemperatures(t *testing.T) {
+	inventoryData, err := os.ReadFile("testdata/hardware-inventory.json")
+	if err != nil {
+		t.Fatalf("Error loading fixture data: %s", err.Error())
+	}
+	temperatureData, err := os.ReadFile("testdata/enclosure-temperatures.json")
+	if err != nil {
+		t.Fatalf("Error loading fixture data: %s", err.Error())
+	}
+	expected := `# HELP eseries_thermal_sensor_temperature_celsius Temperature reported by the thermal sensor
+# TYPE eseries_thermal_sensor_temperature_celsius gauge
+eseries_thermal_sensor_temperature_celsius{sensor="CPU",slot="1",tray="99"} 41
+eseries_thermal_sensor_temperature_celsius{sensor="inlet",slot="2",tray="99"} 24
+# HELP eseries_exporter_collect_error Indicates if error has occurred during collection
+# TYPE eseries_exporter_collect_error gauge
+eseries_exporter_collect_error{collector="hardware-inventory"} 0
+`
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if strings.HasSuffix(req.URL.Path, "getEnclosureTemperatures") && req.Method == http.MethodPost {
+			_, _ = rw.Write(temperatureData)
+		} else {
+			_, _ = rw.Write(inventoryData)
+		}
+	}))
+	defer server.Close()
+	baseURL, _ := url.Parse(server.URL)
+	target := config.Target{
+		Name:       "test",
+		User:       "test",
+		Password:   "test",
+		BaseURL:    baseURL,
+		HttpClient: &http.Client{},
+	}
+	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+	collector := NewHardwareInventoryExporter(target, logger)
+	gatherers := setupGatherer(collector)
+	if err := testutil.GatherAndCompare(gatherers, strings.NewReader(expected),
+		"eseries_thermal_sensor_temperature_celsius", "eseries_exporter_collect_error"); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+}
+
+func TestHardwareInventoryCollectorTemperaturesError(t *testing.T) {
+	inventoryData, err := os.ReadFile("testdata/hardware-inventory.json")
+	if err != nil {
+		t.Fatalf("Error loading fixture data: %s", err.Error())
+	}
+	expected := `# HELP eseries_exporter_collect_error Indicates if error has occurred during collection
+# TYPE eseries_exporter_collect_error gauge
+eseries_exporter_collect_error{collector="hardware-inventory"} 1
+`
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if strings.HasSuffix(req.URL.Path, "getEnclosureTemperatures") {
+			http.Error(rw, "error", http.StatusInternalServerError)
+		} else {
+			_, _ = rw.Write(inventoryData)
+		}
+	}))
+	defer server.Close()
+	baseURL, _ := url.Parse(server.URL)
+	target := config.Target{
+		Name:       "test",
+		User:       "test",
+		Password:   "test",
+		BaseURL:    baseURL,
+		HttpClient: &http.Client{},
+	}
+	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+	collector := NewHardwareInventoryExporter(target, logger)
+	gatherers := setupGatherer(collector)
+	if err := testutil.GatherAndCompare(gatherers, strings.NewReader(expected),
+		"eseries_thermal_sensor_temperature_celsius", "eseries_exporter_collect_error"); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+}
+
+func TestHardwareInventoryCollectorDriveTemperatures(t *testing.T) {
+	fixtureData, err := os.ReadFile("testdata/drives-duplicate.json")
+	if err != nil {
+		t.Fatalf("Error loading fixture data: %s", err.Error())
+	}
+	expected := `# HELP eseries_drive_temperature_celsius Current drive temperature
+# TYPE eseries_drive_temperature_celsius gauge
+eseries_drive_temperature_celsius{slot="53",tray="0"} 35
+eseries_drive_temperature_celsius{slot="58",tray="0"} 27
+# HELP eseries_tray_drive_temperature_max_celsius Highest drive temperature in the tray
+# TYPE eseries_tray_drive_temperature_max_celsius gauge
+eseries_tray_drive_temperature_max_celsius{tray="0"} 35
+`
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = rw.Write(fixtureData)
+	}))
+	defer server.Close()
+	baseURL, _ := url.Parse(server.URL)
+	target := config.Target{
+		Name:       "test",
+		User:       "test",
+		Password:   "test",
+		BaseURL:    baseURL,
+		HttpClient: &http.Client{},
+	}
+	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+	collector := NewHardwareInventoryExporter(target, logger)
+	gatherers := setupGatherer(collector)
+	if err := testutil.GatherAndCompare(gatherers, strings.NewReader(expected),
+		"eseries_drive_temperature_celsius", "eseries_tray_drive_temperature_max_celsius"); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+}
@@ -0,0 +1,270 @@
+package collector
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/sckyzo/eseries_exporter/internal/config"
+)
+
+type Host struct {
+	ID            string          `json:"id"`
+	Label         string          `json:"label"`
+	ClusterRef    string          `json:"clusterRef"`
+	HostTypeIndex int             `json:"hostTypeIndex"`
+	Initiators    []HostInitiator `json:"initiators"`
+}
+
+type HostInitiator struct {
+	ID       string            `json:"id"`
+	Label    string            `json:"label"`
+	NodeName InitiatorNodeName `json:"nodeName"`
+}
+
+type InitiatorNodeName struct {
+	IoInterfaceType string `json:"ioInterfaceType"`
+	IscsiNodeName   string `json:"iscsiNodeName"`
+	RemoteNodeWWN   string `json:"remoteNodeWWN"`
+	NvmeNodeName    string `json:"nvmeNodeName"`
+}
+
+// name returns the initiator label, or its node name when unlabeled.
+func (i HostInitiator) name() string {
+	if i.Label != "" {
+		return i.Label
+	}
+	for _, n := range []string{i.NodeName.RemoteNodeWWN, i.NodeName.IscsiNodeName, i.NodeName.NvmeNodeName} {
+		if n != "" {
+			return n
+		}
+	}
+	return i.ID
+}
+
+type HostGroup struct {
+	ID    string `json:"id"`
+	Label string `json:"label"`
+}
+
+type HostType struct {
+	Index int    `json:"index"`
+	Name  string `json:"name"`
+	Code  string `json:"code"`
+}
+
+type LUNMapping struct {
+	ID        string `json:"id"`
+	LUN       int    `json:"lun"`
+	VolumeRef string `json:"volumeRef"`
+	MapRef    string `json:"mapRef"`
+	Type      string `json:"type"`
+}
+
+type HostConnectivity struct {
+	HostRef       string `json:"hostRef"`
+	InitiatorRef  string `json:"initiatorRef"`
+	ControllerRef string `json:"controllerRef"`
+	LoggedIn      bool   `json:"loggedIn"`
+}
+
+type HostsInventory struct {
+	Hosts        []Host
+	HostGroups   []HostGroup
+	HostTypes    []HostType
+	Mappings     []LUNMapping
+	Volumes      []Volume
+	ThinVolumes  []ThinVolume
+	Controllers  []Controller
+	Connectivity []HostConnectivity
+}
+
+type HostsCollector struct {
+	Info              *prometheus.Desc
+	Initiators        *prometheus.Desc
+	VolumeMapping     *prometheus.Desc
+	InitiatorLoggedIn *prometheus.Desc
+	RedundantPaths    *prometheus.Desc
+	target            config.Target
+	logger            *slog.Logger
+}
+
+func init() {
+	registerCollector("hosts", false, NewHostsExporter)
+}
+
+func NewHostsExporter(target config.Target, logger *slog.Logger) Collector {
+	return &HostsCollector{
+		Info: prometheus.NewDesc(prometheus.BuildFQName(namespace, "host", "info"),
+			"Host definition, value is always 1", []string{"host", "host_group", "host_type"}, nil),
+		Initiators: prometheus.NewDesc(prometheus.BuildFQName(namespace, "host", "initiators"),
+			"Number of initiators defined for the host", []string{"host", "host_group", "type"}, nil),
+		VolumeMapping: prometheus.NewDesc(prometheus.BuildFQName(namespace, "volume", "mapping_info"),
+			"Volume to host or host group LUN mapping, value is always 1", []string{"volume", "host", "host_group", "lun"}, nil),
+		InitiatorLoggedIn: prometheus.NewDesc(prometheus.BuildFQName(namespace, "host", "initiator_logged_in"),
+			"Whether the host initiator is logged in to the controller (1) or not (0)",
+			[]string{"host", "host_group", "initiator", "controller", "controller_label"}, nil),
+		RedundantPaths: prometheus.NewDesc(prometheus.BuildFQName(namespace, "host", "redundant_paths"),
+			"Whether the host has an initiator logged in to every controller (1) or not (0)", []string{"host", "host_group"}, nil),
+		target: target,
+		logger: logger,
+	}
+}
+
+func (c *HostsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.Info
+	ch <- c.Initiators
+	ch <- c.VolumeMapping
+	ch <- c.InitiatorLoggedIn
+	ch <- c.RedundantPaths
+}
+
+func (c *HostsCollector) Collect(ch chan<- prometheus.Metric) {
+	c.logger.Debug("Collecting hosts metrics")
+	collectTime := time.Now()
+	var errorMetric int
+	inventory, err := c.collect()
+	if err != nil {
+		c.logger.Error("Collection failed", "error", err)
+		errorMetric = 1
+	}
+
+	groups := make(map[string]string)
+	for _, g := range inventory.HostGroups {
+		groups[g.ID] = g.Label
+	}
+	hostTypes := make(map[int]string)
+	for _, t := range inventory.HostTypes {
+		hostTypes[t.Index] = t.Name
+	}
+	hosts := make(map[string]Host)
+	for _, h := range inventory.Hosts {
+		hosts[h.ID] = h
+		hostType, ok := hostTypes[h.HostTypeIndex]
+		if !ok {
+			hostType = strconv.Itoa(h.HostTypeIndex)
+		}
+		ch <- prometheus.MustNewConstMetric(c.Info, prometheus.GaugeValue, 1, h.Label, groups[h.ClusterRef], hostType)
+		initiators := make(map[string]float64)
+		for _, i := range h.Initiators {
+			initiators[i.NodeName.IoInterfaceType]++
+		}
+		for initiatorType, count := range initiators {
+			ch <- prometheus.MustNewConstMetric(c.Initiators, prometheus.GaugeValue, count, h.Label, groups[h.ClusterRef], initiatorType)
+		}
+	}
+
+	volumes := addThinVolumeLabels(volumeLabels(inventory.Volumes), inventory.ThinVolumes)
+	for _, m := range inventory.Mappings {
+		var host, hostGroup string
+		switch m.Type {
+		case "host":
+			if h, ok := hosts[m.MapRef]; ok {
+				host, hostGroup = h.Label, groups[h.ClusterRef]
+			} else {
+				host = m.MapRef
+			}
+		case "cluster":
+			hostGroup = lookupLabel(groups, m.MapRef)
+		}
+		ch <- prometheus.MustNewConstMetric(c.VolumeMapping, prometheus.GaugeValue, 1,
+			lookupLabel(volumes, m.VolumeRef), host, hostGroup, strconv.Itoa(m.LUN))
+	}
+
+	if inventory.Connectivity != nil {
+		c.collectConnectivity(ch, inventory, groups)
+	}
+
+	ch <- prometheus.MustNewConstMetric(collectError, prometheus.GaugeValue, float64(errorMetric), "hosts")
+	ch <- prometheus.MustNewConstMetric(collectDuration, prometheus.GaugeValue, time.Since(collectTime).Seconds(), "hosts")
+}
+
+func (c *HostsCollector) collectConnectivity(ch chan<- prometheus.Metric, inventory HostsInventory, groups map[string]string) {
+	loggedIn := make(map[string]bool)
+	for _, l := range inventory.Connectivity {
+		if l.LoggedIn {
+			loggedIn[l.InitiatorRef+"/"+l.ControllerRef] = true
+		}
+	}
+	for _, h := range inventory.Hosts {
+		controllersReached := 0
+		for _, controller := range inventory.Controllers {
+			reached := false
+			for _, i := range h.Initiators {
+				var value float64
+				if loggedIn[i.ID+"/"+controller.ID] {
+					value = 1
+					reached = true
+				}
+				ch <- prometheus.MustNewConstMetric(c.InitiatorLoggedIn, prometheus.GaugeValue, value,
+					h.Label, groups[h.ClusterRef], i.name(), controller.ID, controller.PhysicalLocation.Label)
+			}
+			if reached {
+				controllersReached++
+			}
+		}
+		var redundant float64
+		if len(inventory.Controllers) > 1 && controllersReached == len(inventory.Controllers) {
+			redundant = 1
+		}
+		ch <- prometheus.MustNewConstMetric(c.RedundantPaths, prometheus.GaugeValue, redundant, h.Label, groups[h.ClusterRef])
+	}
+}
+
+func (c *HostsCollector) collect() (HostsInventory, error) {
+	var inventory HostsInventory
+	bodies, err := getRequests(c.target, []string{
+		fmt.Sprintf("/devmgr/v2/storage-systems/%s/hosts", c.target.Name),
+		fmt.Sprintf("/devmgr/v2/storage-systems/%s/host-groups", c.target.Name),
+		fmt.Sprintf("/devmgr/v2/storage-systems/%s/host-types", c.target.Name),
+		fmt.Sprintf("/devmgr/v2/storage-systems/%s/volume-mappings", c.target.Name),
+		fmt.Sprintf("/devmgr/v2/storage-systems/%s/volumes", c.target.Name),
+		fmt.Sprintf("/devmgr/v2/storage-systems/%s/thin-volumes", c.target.Name),
+	}, c.logger)
+	if err != nil {
+		return inventory, err
+	}
+	if err := json.Unmarshal(bodies[0], &inventory.Hosts); err != nil {
+		return inventory, fmt.Errorf("failed to unmarshal hosts: %w", err)
+	}
+	if err := json.Unmarshal(bodies[1], &inventory.HostGroups); err != nil {
+		return inventory, fmt.Errorf("failed to unmarshal host groups: %w", err)
+	}
+	if err := json.Unmarshal(bodies[2], &inventory.HostTypes); err != nil {
+		return inventory, fmt.Errorf("failed to unmarshal host types: %w", err)
+	}
+	if err := json.Unmarshal(bodies[3], &inventory.Mappings); err != nil {
+		return inventory, fmt.Errorf("failed to unmarshal volume mappings: %w", err)
+	}
+	if err := json.Unmarshal(bodies[4], &inventory.Volumes); err != nil {
+		return inventory, fmt.Errorf("failed to unmarshal volumes: %w", err)
+	}
+	if err := json.Unmarshal(bodies[5], &inventory.ThinVolumes); err != nil {
+		return inventory, fmt.Errorf("failed to unmarshal thin volumes: %w", err)
+	}
+	// Connectivity reporting requires firmware 11.60 or later, its absence is not an error
+	connectivityBody, err := getOptionalRequest(c.target,
+		fmt.Sprintf("/devmgr/v2/storage-systems/%s/host-connectivity-report", c.target.Name), c.logger)
+	if isNotFound(err) {
+		return inventory, nil
+	} else if err != nil {
+		return inventory, err
+	}
+	inventoryBody, err := getRequest(c.target, fmt.Sprintf("/devmgr/v2/storage-systems/%s/hardware-inventory", c.target.Name), c.logger)
+	if err != nil {
+		return inventory, err
+	}
+	var controllers ControllersInventory
+	if err := json.Unmarshal(inventoryBody, &controllers); err != nil {
+		return inventory, fmt.Errorf("failed to unmarshal hardware inventory: %w", err)
+	}
+	inventory.Controllers = controllers.Controllers
+	if err := json.Unmarshal(connectivityBody, &inventory.Connectivity); err != nil {
+		return inventory, fmt.Errorf("failed to unmarshal host connectivity report: %w", err)
+	}
+	return inventory, nil
+}
@@ -0,0 +1,229 @@
+package collector
+
+import (
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/sckyzo/eseries_exporter/internal/config"
+)
+
+func TestHostsCollector(t *testing.T) {
+	fixtures := make(map[string][]byte)
+	for path, file := range map[string]string{
+		"hosts":           "testdata/hosts.json",
+		"host-groups":     "testdata/host-groups.json",
+		"host-types":      "testdata/host-types.json",
+		"volume-mappings": "testdata/volume-mappings.json",
+		"volumes":         "testdata/volumes-response.json",
+		"thin-volumes":    "testdata/thin-volumes-response.json",
+	} {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatalf("Error loading fixture data: %s", err.Error())
+		}
+		fixtures[path] = data
+	}
+	expected := `# HELP eseries_host_info Host definition, value is always 1
+# TYPE eseries_host_info gauge
+eseries_host_info{host="backup01",host_group="",host_type="Windows"} 1
+eseries_host_info{host="compute01",host_group="compute",host_type="Linux DM-MP (Kernel 3.10 or later)"} 1
+# HELP eseries_host_initiators Number of initiators defined for the host
+# TYPE eseries_host_initiators gauge
+eseries_host_initiators{host="backup01",host_group="",type="iscsi"} 1
+eseries_host_initiators{host="compute01",host_group="compute",type="fc"} 2
+# HELP eseries_volume_mapping_info Volume to host or host group LUN mapping, value is always 1
+# TYPE eseries_volume_mapping_info gauge
+eseries_volume_mapping_info{host="",host_group="compute",lun="1",volume="Volume_1"} 1
+eseries_volume_mapping_info{host="backup01",host_group="",lun="0",volume="Volume_2"} 1
+eseries_volume_mapping_info{host="backup01",host_group="",lun="2",volume="Thin_1"} 1
+# HELP eseries_exporter_collect_error Indicates if error has occurred during collection
+# TYPE eseries_exporter_collect_error gauge
+eseries_exporter_collect_error{collector="hosts"} 0
+`
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		path := strings.TrimPrefix(req.URL.Path, "/devmgr/v2/storage-systems/test/")
+		data, ok := fixtures[path]
+		if !ok {
+			http.Error(rw, "not found", http.StatusNotFound)
+			return
+		}
+		_, _ = rw.Write(data)
+	}))
+	defer server.Close()
+	baseURL, _ := url.Parse(server.URL)
+	target := config.Target{
+		Name:       "test",
+		User:       "test",
+		Password:   "test",
+		BaseURL:    baseURL,
+		HttpClient: &http.Client{},
+	}
+	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+	collector := NewHostsExporter(target, logger)
+	gatherers := setupGatherer(collector)
+	if val, err := testutil.GatherAndCount(gatherers); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	} else if val != 9 {
+		t.Errorf("Unexpected collection count %d, expected 9", val)
+	}
+	if err := testutil.GatherAndCompare(gatherers, strings.NewReader(expected),
+		"eseries_host_info", "eseries_host_initiators", "eseries_volume_mapping_info", "eseries_exporter_collect_error"); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+}
+
+func TestHostsCollectorConnectivity(t *testing.T) {
+	fixtures := make(map[string][]byte)
+	for path, file := range map[string]string{
+		"hosts":                    "testdata/hosts.json",
+		"host-groups":              "testdata/host-groups.json",
+		"host-types":               "testdata/host-types.json",
+		"volume-mappings":          "testdata/volume-mappings.json",
+		"volumes":                  "testdata/volumes-response.json",
+		"thin-volumes":             "testdata/thin-volumes-response.json",
+		"host-connectivity-report": "testdata/host-connectivity-report.json",
+		"hardware-inventory":       "testdata/controllers.json",
+	} {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatalf("Error loading fixture data: %s", err.Error())
+		}
+		fixtures[path] = data
+	}
+	expected := `# HELP eseries_host_initiator_logged_in Whether the host initiator is logged in to the controller (1) or not (0)
+# TYPE eseries_host_initiator_logged_in gauge
+eseries_host_initiator_logged_in{controller="070000000000000000000001",controller_label="A",host="backup01",host_group="",initiator="backup01_iscsi"} 1
+eseries_host_initiator_logged_in{controller="070000000000000000000001",controller_label="A",host="compute01",host_group="compute",initiator="compute01_port0"} 1
+eseries_host_initiator_logged_in{controller="070000000000000000000001",controller_label="A",host="compute01",host_group="compute",initiator="compute01_port1"} 0
+eseries_host_initiator_logged_in{controller="070000000000000000000002",controller_label="B",host="backup01",host_group="",initiator="backup01_iscsi"} 0
+eseries_host_initiator_logged_in{controller="070000000000000000000002",controller_label="B",host="compute01",host_group="compute",initiator="compute01_port0"} 0
+eseries_host_initiator_logged_in{controller="070000000000000000000002",controller_label="B",host="compute01",host_group="compute",initiator="compute01_port1"} 1
+# HELP eseries_host_redundant_paths Whether the host has an initiator logged in to every controller (1) or not (0)
+# TYPE eseries_host_redundant_paths gauge
+eseries_host_redundant_paths{host="backup01",host_group=""} 0
+eseries_host_redundant_paths{host="compute01",host_group="compute"} 1
+# HELP eseries_exporter_collect_error Indicates if error has occurred during collection
+# TYPE eseries_exporter_collect_error gauge
+eseries_exporter_collect_error{collector="hosts"} 0
+`
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		path := strings.TrimPrefix(req.URL.Path, "/devmgr/v2/storage-systems/test/")
+		data, ok := fixtures[path]
+		if !ok {
+			http.Error(rw, "not found", http.StatusNotFound)
+			return
+		}
+		_, _ = rw.Write(data)
+	}))
+	defer server.Close()
+	baseURL, _ := url.Parse(server.URL)
+	target := config.Target{
+		Name:       "test",
+		User:       "test",
+		Password:   "test",
+		BaseURL:    baseURL,
+		HttpClient: &http.Client{},
+	}
+	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+	collector := NewHostsExporter(target, logger)
+	gatherers := setupGatherer(collector)
+	if val, err := testutil.GatherAndCount(gatherers); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	} else if val != 17 {
+		t.Errorf("Unexpected collection count %d, expected 17", val)
+	}
+	if err := testutil.GatherAndCompare(gatherers, strings.NewReader(expected),
+		"eseries_host_initiator_logged_in", "eseries_host_redundant_paths", "eseries_exporter_collect_error"); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+}
+
+func TestHostsCollectorError(t *testing.T) {
+	expected := `# HELP eseries_exporter_collect_error Indicates if error has occurred during collection
+# TYPE eseries_exporter_collect_error gauge
+eseries_exporter_collect_error{collector="hosts"} 1
+`
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		http.Error(rw, "error", http.StatusNotFound)
+	}))
+	defer server.Close()
+	baseURL, _ := url.Parse(server.URL)
+	target := config.Target{
+		Name:       "test",
+		User:       "test",
+		Password:   "test",
+		BaseURL:    baseURL,
+		HttpClient: &http.Client{},
+	}
+	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+	collector := NewHostsExporter(target, logger)
+	gatherers := setupGatherer(collector)
+	if val, err := testutil.GatherAndCount(gatherers); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	} else if val != 2 {
+		t.Errorf("Unexpected collection count %d, expected 2", val)
+	}
+	if err := testutil.GatherAndCompare(gatherers, strings.NewReader(expected),
+		"eseries_host_info", "eseries_exporter_collect_error"); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+}
+
+func TestHostsCollectorConnectivityError(t *testing.T) {
+	fixtures := make(map[string][]byte)
+	for path, file := range map[string]string{
+		"hosts":           "testdata/hosts.json",
+		"host-groups":     "testdata/host-groups.json",
+		"host-types":      "testdata/host-types.json",
+		"volume-mappings": "testdata/volume-mappings.json",
+		"volumes":         "testdata/volumes-response.json",
+		"thin-volumes":    "testdata/thin-volumes-response.json",
+	} {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatalf("Error loading fixture data: %s", err.Error())
+		}
+		fixtures[path] = data
+	}
+	expected := `# HELP eseries_exporter_collect_error Indicates if error has occurred during collection
+# TYPE eseries_exporter_collect_error gauge
+eseries_exporter_collect_error{collector="hosts"} 1
+`
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		path := strings.TrimPrefix(req.URL.Path, "/devmgr/v2/storage-systems/test/")
+		data, ok := fixtures[path]
+		if !ok {
+			http.Error(rw, "error", http.StatusInternalServerError)
+			return
+		}
+		_, _ = rw.Write(data)
+	}))
+	defer server.Close()
+	baseURL, _ := url.Parse(server.URL)
+	target := config.Target{
+		Name:       "test",
+		User:       "test",
+		Password:   "test",
+		BaseURL:    baseURL,
+		HttpClient: &http.Client{},
+	}
+	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+	collector := NewHostsExporter(target, logger)
+	gatherers := setupGatherer(collector)
+	if err := testutil.GatherAndCompare(gatherers, strings.NewReader(expected),
+		"eseries_host_initiator_logged_in", "eseries_host_redundant_paths", "eseries_exporter_collect_error"); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+	if val, err := testutil.GatherAndCount(gatherers, "eseries_host_info"); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	} else if val == 0 {
+		t.Errorf("Expected host inventory when the connectivity report fails")
+	}
+}
@@ -0,0 +1,148 @@
+package collector
+
+import (
+	"bytes"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+)
+
+// influxSubsystems lists the subsystems made of several words, longest first, so
+// they are not split at their first underscore when naming measurements. The
+// hardware components are added at init.
+var influxSubsystems = []string{
+	"async_mirror_group", "consistency_group", "snapshot_group", "volume_mapping", "storage_system",
+	"flash_cache", "sync_mirror", "thin_volume",
+}
+
+func init() {
+	for _, component := range append([]StatusComponent{driveComponent}, hardwareInventoryComponents...) {
+		if strings.Contains(component.Name, "_") && !sliceContains(influxSubsystems, component.Name) {
+			influxSubsystems = append(influxSubsystems, component.Name)
+		}
+	}
+	sort.SliceStable(influxSubsystems, func(i, j int) bool { return len(influxSubsystems[i]) > len(influxSubsystems[j]) })
+}
+
+var (
+	influxMeasurementEscaper = strings.NewReplacer(",", `\,`, " ", `\ `)
+	influxKeyEscaper         = strings.NewReplacer(",", `\,`, "=", `\=`, " ", `\ `)
+)
+
+// influxPoint is one line of line protocol: the samples of a measurement sharing
+// tags and timestamp.
+type influxPoint struct {
+	measurement string
+	tags        string
+	timestamp   int64
+	fields      []string
+}
+
+// WriteInflux encodes families as InfluxDB line protocol. The measurement is the
+// namespace and subsystem of the metric name, eseries_drive for
+// eseries_drive_read_bytes_total, and the rest of the name the field. Labels
+// become tags and samples without a timestamp are stamped with now. A field
+// named like one of its tags, the status of one-hot status families, is named
+// value instead so the two can be told apart. Samples
+// sharing measurement, tags and timestamp are written as one line. Histograms,
+// summaries and non-finite values, which line protocol cannot represent, are
+// skipped.
+func WriteInflux(w io.Writer, families []*dto.MetricFamily, now time.Time) error {
+	var points []*influxPoint
+	index := make(map[string]*influxPoint)
+	for _, family := range families {
+		measurement, field := influxMeasurement(family.GetName())
+		for _, m := range family.GetMetric() {
+			var value float64
+			switch family.GetType() {
+			case dto.MetricType_COUNTER:
+				value = m.GetCounter().GetValue()
+			case dto.MetricType_GAUGE:
+				value = m.GetGauge().GetValue()
+			case dto.MetricType_UNTYPED:
+				value = m.GetUntyped().GetValue()
+			default:
+				continue
+			}
+			if math.IsNaN(value) || math.IsInf(value, 0) {
+				continue
+			}
+			timestamp := now.UnixNano()
+			if m.TimestampMs != nil {
+				timestamp = time.UnixMilli(m.GetTimestampMs()).UnixNano()
+			}
+			tags := influxTags(m.GetLabel())
+			name := field
+			for _, l := range m.GetLabel() {
+				if l.GetName() == field {
+					name = "value"
+				}
+			}
+			key := measurement + tags + " " + strconv.FormatInt(timestamp, 10)
+			point, ok := index[key]
+			if !ok {
+				point = &influxPoint{measurement: measurement, tags: tags, timestamp: timestamp}
+				index[key] = point
+				points = append(points, point)
+			}
+			point.fields = append(point.fields, influxKeyEscaper.Replace(name)+"="+strconv.FormatFloat(value, 'g', -1, 64))
+		}
+	}
+
+	var buf bytes.Buffer
+	for _, p := range points {
+		buf.WriteString(influxMeasurementEscaper.Replace(p.measurement))
+		buf.WriteString(p.tags)
+		buf.WriteByte(' ')
+		buf.WriteString(strings.Join(p.fields, ","))
+		buf.WriteByte(' ')
+		buf.WriteString(strconv.FormatInt(p.timestamp, 10))
+		buf.WriteByte('\n')
+	}
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// influxMeasurement splits a metric name into its namespace and subsystem, and
+// the remaining field name.
+func influxMeasurement(name string) (string, string) {
+	prefix := namespace + "_"
+	if !strings.HasPrefix(name, prefix) {
+		return name, "value"
+	}
+	rest := strings.TrimPrefix(name, prefix)
+	for _, subsystem := range influxSubsystems {
+		if strings.HasPrefix(rest, subsystem+"_") {
+			return prefix + subsystem, strings.TrimPrefix(rest, subsystem+"_")
+		}
+	}
+	if subsystem, field, ok := strings.Cut(rest, "_"); ok {
+		return prefix + subsystem, field
+	}
+	return name, "value"
+}
+
+// influxTags encodes labels as tags sorted by key. Line protocol has no empty tag
+// values, so empty labels are omitted.
+func influxTags(labels []*dto.LabelPair) string {
+	sorted := make([]*dto.LabelPair, 0, len(labels))
+	for _, l := range labels {
+		if l.GetValue() != "" {
+			sorted = append(sorted, l)
+		}
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].GetName() < sorted[j].GetName() })
+	var b strings.Builder
+	for _, l := range sorted {
+		b.WriteByte(',')
+		b.WriteString(influxKeyEscaper.Replace(l.GetName()))
+		b.WriteByte('=')
+		b.WriteString(influxKeyEscaper.Replace(l.GetValue()))
+	}
+	return b.String()
+}
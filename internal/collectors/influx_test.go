@@ -0,0 +1,68 @@
+package collector
+
+import (
+	"bytes"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestWriteInflux(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	status := prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: "eseries_drive_status", Help: "Status of drive hardware device"},
+		[]string{"tray", "slot", "status"})
+	status.WithLabelValues("0", "1", "optimal").Set(1)
+	readBytes := prometheus.NewCounterVec(prometheus.CounterOpts{Name: "eseries_drive_read_bytes_total", Help: "Drive statistic readBytes"},
+		[]string{"tray", "slot"})
+	readBytes.WithLabelValues("0", "1").Add(1024)
+	writeBytes := prometheus.NewCounterVec(prometheus.CounterOpts{Name: "eseries_drive_write_bytes_total", Help: "Drive statistic writeBytes"},
+		[]string{"tray", "slot"})
+	writeBytes.WithLabelValues("0", "1").Add(512)
+	score := prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: "eseries_drive_outlier_score", Help: "Drive outlier score"},
+		[]string{"tray", "slot"})
+	score.WithLabelValues("0", "1").Set(math.Inf(1))
+	systemUsed := prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: "eseries_storage_system_used_bytes", Help: "Used capacity"},
+		[]string{"name", "workload"})
+	systemUsed.WithLabelValues("Array 1", "").Set(2048)
+	timestamped := prometheus.NewDesc("eseries_system_read_iops", "System read IOPS", nil, nil)
+	registry.MustRegister(status, readBytes, writeBytes, score, systemUsed, prometheus.CollectorFunc(func(ch chan<- prometheus.Metric) {
+		ch <- prometheus.NewMetricWithTimestamp(time.Unix(1699999990, 0),
+			prometheus.MustNewConstMetric(timestamped, prometheus.GaugeValue, 10))
+	}))
+	families, err := registry.Gather()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := `eseries_drive,slot=1,tray=0 read_bytes_total=1024,write_bytes_total=512 1700000000000000000
+eseries_drive,slot=1,status=optimal,tray=0 value=1 1700000000000000000
+eseries_storage_system,name=Array\ 1 used_bytes=2048 1700000000000000000
+eseries_system read_iops=10 1699999990000000000
+`
+	var buf bytes.Buffer
+	if err := WriteInflux(&buf, families, time.Unix(1700000000, 0)); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if buf.String() != expected {
+		t.Errorf("Unexpected influx output:\n%s\nExpected:\n%s", buf.String(), expected)
+	}
+}
+
+func TestInfluxMeasurement(t *testing.T) {
+	for name, expected := range map[string][2]string{
+		"eseries_drive_read_bytes_total":              {"eseries_drive", "read_bytes_total"},
+		"eseries_cache_memory_dimm_status":            {"eseries_cache_memory_dimm", "status"},
+		"eseries_power_supply_status":                 {"eseries_power_supply", "status"},
+		"eseries_async_mirror_group_link_up":          {"eseries_async_mirror_group", "link_up"},
+		"eseries_volume_mapping_info":                 {"eseries_volume_mapping", "info"},
+		"eseries_volume_capacity_bytes":               {"eseries_volume", "capacity_bytes"},
+		"eseries_exporter_collector_duration_seconds": {"eseries_exporter", "collector_duration_seconds"},
+		"up": {"up", "value"},
+	} {
+		measurement, field := influxMeasurement(name)
+		if measurement != expected[0] || field != expected[1] {
+			t.Errorf("Unexpected measurement %s and field %s for %s, expected %v", measurement, field, name, expected)
+		}
+	}
+}
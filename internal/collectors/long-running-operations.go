@@ -0,0 +1,121 @@
+package collector
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/sckyzo/eseries_exporter/internal/config"
+)
+
+// ActionProgress is a long-running operation reported for a pool or one of its volumes.
+type ActionProgress struct {
+	VolumeRef string `json:"volumeRef"`
+	// ProgressPercentage is 0-100
+	ProgressPercentage float64 `json:"progressPercentage"`
+	// EstimatedTimeToCompletion is in minutes, negative when the array has no estimate
+	EstimatedTimeToCompletion float64 `json:"estimatedTimeToCompletion"`
+	CurrentAction             string  `json:"currentAction"`
+}
+
+// PoolActionProgress holds the operations running in a storage pool.
+type PoolActionProgress struct {
+	Pool    StoragePool
+	Actions []ActionProgress
+}
+
+type LongRunningOperationsCollector struct {
+	Progress  *prometheus.Desc
+	Remaining *prometheus.Desc
+	target    config.Target
+	logger    *slog.Logger
+}
+
+func init() {
+	registerCollector("long-running-operations", false, NewLongRunningOperationsExporter)
+}
+
+func NewLongRunningOperationsExporter(target config.Target, logger *slog.Logger) Collector {
+	labels := []string{"pool", "volume", "operation"}
+	return &LongRunningOperationsCollector{
+		Progress: prometheus.NewDesc(prometheus.BuildFQName(namespace, "operation", "progress_ratio"),
+			"Completion ratio (0-1) of a long-running operation such as reconstruction, copyback, expansion, defragmentation or initialization, volume is empty for pool operations",
+			labels, nil),
+		Remaining: prometheus.NewDesc(prometheus.BuildFQName(namespace, "operation", "remaining_seconds"),
+			"Estimated time remaining for a long-running operation in seconds, volume is empty for pool operations",
+			labels, nil),
+		target: target,
+		logger: logger,
+	}
+}
+
+func (c *LongRunningOperationsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.Progress
+	ch <- c.Remaining
+}
+
+func (c *LongRunningOperationsCollector) Collect(ch chan<- prometheus.Metric) {
+	c.logger.Debug("Collecting long-running operations metrics")
+	collectTime := time.Now()
+	var errorMetric int
+	progress, volumes, err := c.collect()
+	if err != nil {
+		c.logger.Error("Collection failed", "error", err)
+		errorMetric = 1
+	}
+
+	labels := volumeLabels(volumes)
+	for _, p := range progress {
+		for _, a := range p.Actions {
+			if a.CurrentAction == "" || a.CurrentAction == "none" {
+				continue
+			}
+			var volume string
+			if a.VolumeRef != "" && a.VolumeRef != p.Pool.ID {
+				volume = lookupLabel(labels, a.VolumeRef)
+			}
+			ch <- prometheus.MustNewConstMetric(c.Progress, prometheus.GaugeValue, a.ProgressPercentage/100,
+				p.Pool.Label, volume, a.CurrentAction)
+			if a.EstimatedTimeToCompletion >= 0 {
+				ch <- prometheus.MustNewConstMetric(c.Remaining, prometheus.GaugeValue, a.EstimatedTimeToCompletion*60,
+					p.Pool.Label, volume, a.CurrentAction)
+			}
+		}
+	}
+
+	ch <- prometheus.MustNewConstMetric(collectError, prometheus.GaugeValue, float64(errorMetric), "long-running-operations")
+	ch <- prometheus.MustNewConstMetric(collectDuration, prometheus.GaugeValue, time.Since(collectTime).Seconds(), "long-running-operations")
+}
+
+func (c *LongRunningOperationsCollector) collect() ([]PoolActionProgress, []Volume, error) {
+	var pools []StoragePool
+	var volumes []Volume
+	responses := getResponses(c.target, []request{
+		{path: fmt.Sprintf("/devmgr/v2/storage-systems/%s/storage-pools", c.target.Name)},
+		{path: fmt.Sprintf("/devmgr/v2/storage-systems/%s/volumes", c.target.Name)},
+	}, c.logger)
+	errs := []error{
+		responses[0].decode(&pools),
+		responses[1].decode(&volumes),
+	}
+
+	// Progress is reported per pool and covers the pool and the volumes it holds
+	requests := make([]request, 0, len(pools))
+	for _, p := range pools {
+		requests = append(requests, request{path: fmt.Sprintf("/devmgr/v2/storage-systems/%s/storage-pools/%s/action-progress", c.target.Name, p.ID)})
+	}
+	responses = getResponses(c.target, requests, c.logger)
+	progress := make([]PoolActionProgress, 0, len(pools))
+	for i, p := range pools {
+		pp := PoolActionProgress{Pool: p}
+		if err := responses[i].decode(&pp.Actions); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		progress = append(progress, pp)
+	}
+	return progress, volumes, errors.Join(errs...)
+}
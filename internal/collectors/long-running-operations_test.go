@@ -0,0 +1,103 @@
+package collector
+
+import (
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/sckyzo/eseries_exporter/internal/config"
+)
+
+func TestLongRunningOperationsCollector(t *testing.T) {
+	fixtures := make(map[string][]byte)
+	for path, file := range map[string]string{
+		"storage-pools": "testdata/storage-pools-response.json",
+		"storage-pools/040000006D039EA000CF32BB000000D868E4C6E2/action-progress": "testdata/pool-action-progress.json",
+		"volumes": "testdata/volumes-response.json",
+	} {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatalf("Error loading fixture data: %s", err.Error())
+		}
+		fixtures[path] = data
+	}
+	fixtures["storage-pools/040000006D039EA000CF32BB000000D868E4C6E3/action-progress"] = []byte("[]")
+	expected := `# HELP eseries_operation_progress_ratio Completion ratio (0-1) of a long-running operation such as reconstruction, copyback, expansion, defragmentation or initialization, volume is empty for pool operations
+# TYPE eseries_operation_progress_ratio gauge
+eseries_operation_progress_ratio{operation="initializing",pool="Pool_1",volume="Volume_2"} 0.8
+eseries_operation_progress_ratio{operation="reconstructing",pool="Pool_1",volume=""} 0.42
+# HELP eseries_operation_remaining_seconds Estimated time remaining for a long-running operation in seconds, volume is empty for pool operations
+# TYPE eseries_operation_remaining_seconds gauge
+eseries_operation_remaining_seconds{operation="reconstructing",pool="Pool_1",volume=""} 11100
+# HELP eseries_exporter_collect_error Indicates if error has occurred during collection
+# TYPE eseries_exporter_collect_error gauge
+eseries_exporter_collect_error{collector="long-running-operations"} 0
+`
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		path := strings.TrimPrefix(req.URL.Path, "/devmgr/v2/storage-systems/test/")
+		data, ok := fixtures[path]
+		if !ok {
+			http.Error(rw, "not found", http.StatusNotFound)
+			return
+		}
+		_, _ = rw.Write(data)
+	}))
+	defer server.Close()
+	baseURL, _ := url.Parse(server.URL)
+	target := config.Target{
+		Name:       "test",
+		User:       "test",
+		Password:   "test",
+		BaseURL:    baseURL,
+		HttpClient: &http.Client{},
+	}
+	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+	collector := NewLongRunningOperationsExporter(target, logger)
+	gatherers := setupGatherer(collector)
+	if val, err := testutil.GatherAndCount(gatherers); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	} else if val != 5 {
+		t.Errorf("Unexpected collection count %d, expected 5", val)
+	}
+	if err := testutil.GatherAndCompare(gatherers, strings.NewReader(expected),
+		"eseries_operation_progress_ratio", "eseries_operation_remaining_seconds", "eseries_exporter_collect_error"); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+}
+
+func TestLongRunningOperationsCollectorError(t *testing.T) {
+	expected := `# HELP eseries_exporter_collect_error Indicates if error has occurred during collection
+# TYPE eseries_exporter_collect_error gauge
+eseries_exporter_collect_error{collector="long-running-operations"} 1
+`
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		http.Error(rw, "error", http.StatusNotFound)
+	}))
+	defer server.Close()
+	baseURL, _ := url.Parse(server.URL)
+	target := config.Target{
+		Name:       "test",
+		User:       "test",
+		Password:   "test",
+		BaseURL:    baseURL,
+		HttpClient: &http.Client{},
+	}
+	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+	collector := NewLongRunningOperationsExporter(target, logger)
+	gatherers := setupGatherer(collector)
+	if val, err := testutil.GatherAndCount(gatherers); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	} else if val != 2 {
+		t.Errorf("Unexpected collection count %d, expected 2", val)
+	}
+	if err := testutil.GatherAndCompare(gatherers, strings.NewReader(expected),
+		"eseries_operation_progress_ratio", "eseries_exporter_collect_error"); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+}
@@ -0,0 +1,190 @@
+package collector
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/sckyzo/eseries_exporter/internal/config"
+)
+
+type AsyncMirrorGroup struct {
+	ID                                    string `json:"id"`
+	Label                                 string `json:"label"`
+	LocalRole                             string `json:"localRole"`
+	GroupState                            string `json:"groupState"`
+	SyncActivity                          string `json:"syncActivity"`
+	RemoteTargetName                      string `json:"remoteTargetName"`
+	ConnectionState                       string `json:"connectionState"`
+	LastRecoveryPointTime                 string `json:"lastRecoveryPointTime"`
+	RecoveryPointAgeAlertThresholdMinutes int    `json:"recoveryPointAgeAlertThresholdMinutes"`
+}
+
+type RemoteMirrorPair struct {
+	ID               string `json:"id"`
+	PrimaryVolume    string `json:"primaryVolume"`
+	SecondaryVolume  string `json:"secondaryVolume"`
+	IsPrimary        bool   `json:"isPrimary"`
+	MirrorState      string `json:"mirrorState"`
+	RemoteTargetName string `json:"remoteTargetName"`
+	RemoteVolumeName string `json:"remoteVolumeName"`
+	ConnectionState  string `json:"connectionState"`
+}
+
+// localVolume returns the reference of the mirrored volume held by this array.
+func (p RemoteMirrorPair) localVolume() string {
+	if p.IsPrimary {
+		return p.PrimaryVolume
+	}
+	return p.SecondaryVolume
+}
+
+func (p RemoteMirrorPair) role() string {
+	if p.IsPrimary {
+		return "primary"
+	}
+	return "secondary"
+}
+
+type MirroringCollector struct {
+	GroupRole              *prometheus.Desc
+	GroupStatus            *prometheus.Desc
+	GroupSyncState         *prometheus.Desc
+	GroupLastSync          *prometheus.Desc
+	GroupRecoveryPointAge  *prometheus.Desc
+	GroupRecoveryThreshold *prometheus.Desc
+	GroupLinkUp            *prometheus.Desc
+	PairRole               *prometheus.Desc
+	PairSyncState          *prometheus.Desc
+	PairLinkUp             *prometheus.Desc
+	target                 config.Target
+	logger                 *slog.Logger
+}
+
+func init() {
+	registerCollector("mirroring", false, NewMirroringExporter)
+}
+
+func NewMirroringExporter(target config.Target, logger *slog.Logger) Collector {
+	groupLabels := []string{"group", "remote_system"}
+	pairLabels := []string{"volume", "remote_system", "remote_volume"}
+	return &MirroringCollector{
+		GroupRole: prometheus.NewDesc(prometheus.BuildFQName(namespace, "async_mirror_group", "role"),
+			"Role of this storage system in the async mirror group, value is always 1", append(groupLabels, "role"), nil),
+		GroupStatus: prometheus.NewDesc(prometheus.BuildFQName(namespace, "async_mirror_group", "status"),
+			"Status of the async mirror group (1 for optimal, 0 otherwise)", append(groupLabels, "status"), nil),
+		GroupSyncState: prometheus.NewDesc(prometheus.BuildFQName(namespace, "async_mirror_group", "sync_state"),
+			"Synchronization activity of the async mirror group (1 for active or idle, 0 otherwise)", append(groupLabels, "state"), nil),
+		GroupLastSync: prometheus.NewDesc(prometheus.BuildFQName(namespace, "async_mirror_group", "last_sync_timestamp_seconds"),
+			"Time of the last successful synchronization, the current recovery point", groupLabels, nil),
+		GroupRecoveryPointAge: prometheus.NewDesc(prometheus.BuildFQName(namespace, "async_mirror_group", "recovery_point_age_seconds"),
+			"Age of the current recovery point in seconds", groupLabels, nil),
+		GroupRecoveryThreshold: prometheus.NewDesc(prometheus.BuildFQName(namespace, "async_mirror_group", "recovery_point_age_alert_threshold_seconds"),
+			"Recovery point age in seconds at which the array raises an alert", groupLabels, nil),
+		GroupLinkUp: prometheus.NewDesc(prometheus.BuildFQName(namespace, "async_mirror_group", "link_up"),
+			"Whether the mirror link to the remote storage system is connected (1) or not (0)", groupLabels, nil),
+		PairRole: prometheus.NewDesc(prometheus.BuildFQName(namespace, "sync_mirror", "role"),
+			"Role of the local volume in the synchronous mirror pair, value is always 1", append(pairLabels, "role"), nil),
+		PairSyncState: prometheus.NewDesc(prometheus.BuildFQName(namespace, "sync_mirror", "sync_state"),
+			"Synchronization state of the synchronous mirror pair (1 for optimal, 0 otherwise)", append(pairLabels, "state"), nil),
+		PairLinkUp: prometheus.NewDesc(prometheus.BuildFQName(namespace, "sync_mirror", "link_up"),
+			"Whether the mirror link to the remote storage system is connected (1) or not (0)", pairLabels, nil),
+		target: target,
+		logger: logger,
+	}
+}
+
+func (c *MirroringCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.GroupRole
+	ch <- c.GroupStatus
+	ch <- c.GroupSyncState
+	ch <- c.GroupLastSync
+	ch <- c.GroupRecoveryPointAge
+	ch <- c.GroupRecoveryThreshold
+	ch <- c.GroupLinkUp
+	ch <- c.PairRole
+	ch <- c.PairSyncState
+	ch <- c.PairLinkUp
+}
+
+func (c *MirroringCollector) Collect(ch chan<- prometheus.Metric) {
+	c.logger.Debug("Collecting mirroring metrics")
+	collectTime := time.Now()
+	var errorMetric int
+	groups, pairs, labels, err := c.collect()
+	if err != nil {
+		c.logger.Error("Collection failed", "error", err)
+		errorMetric = 1
+	}
+
+	for _, g := range groups {
+		statusValue := 0.0
+		if g.GroupState == "optimal" {
+			statusValue = 1.0
+		}
+		syncValue := 0.0
+		if g.SyncActivity == "active" || g.SyncActivity == "idle" {
+			syncValue = 1.0
+		}
+		linkValue := 0.0
+		if g.ConnectionState == "connected" {
+			linkValue = 1.0
+		}
+		ch <- prometheus.MustNewConstMetric(c.GroupRole, prometheus.GaugeValue, 1, g.Label, g.RemoteTargetName, g.LocalRole)
+		ch <- prometheus.MustNewConstMetric(c.GroupStatus, prometheus.GaugeValue, statusValue, g.Label, g.RemoteTargetName, g.GroupState)
+		ch <- prometheus.MustNewConstMetric(c.GroupSyncState, prometheus.GaugeValue, syncValue, g.Label, g.RemoteTargetName, g.SyncActivity)
+		// A group that never completed an initial synchronization reports a zero recovery point
+		if lastSync, err := strconv.ParseFloat(g.LastRecoveryPointTime, 64); err == nil && lastSync > 0 {
+			ch <- prometheus.MustNewConstMetric(c.GroupLastSync, prometheus.GaugeValue, lastSync, g.Label, g.RemoteTargetName)
+			age := float64(timeNow().Unix()) - lastSync
+			ch <- prometheus.MustNewConstMetric(c.GroupRecoveryPointAge, prometheus.GaugeValue, age, g.Label, g.RemoteTargetName)
+		}
+		ch <- prometheus.MustNewConstMetric(c.GroupRecoveryThreshold, prometheus.GaugeValue,
+			float64(g.RecoveryPointAgeAlertThresholdMinutes*60), g.Label, g.RemoteTargetName)
+		ch <- prometheus.MustNewConstMetric(c.GroupLinkUp, prometheus.GaugeValue, linkValue, g.Label, g.RemoteTargetName)
+	}
+
+	for _, p := range pairs {
+		volume := lookupLabel(labels, p.localVolume())
+		syncValue := 0.0
+		if p.MirrorState == "optimal" {
+			syncValue = 1.0
+		}
+		linkValue := 0.0
+		if p.ConnectionState == "connected" {
+			linkValue = 1.0
+		}
+		ch <- prometheus.MustNewConstMetric(c.PairRole, prometheus.GaugeValue, 1, volume, p.RemoteTargetName, p.RemoteVolumeName, p.role())
+		ch <- prometheus.MustNewConstMetric(c.PairSyncState, prometheus.GaugeValue, syncValue,
+			volume, p.RemoteTargetName, p.RemoteVolumeName, p.MirrorState)
+		ch <- prometheus.MustNewConstMetric(c.PairLinkUp, prometheus.GaugeValue, linkValue, volume, p.RemoteTargetName, p.RemoteVolumeName)
+	}
+
+	ch <- prometheus.MustNewConstMetric(collectError, prometheus.GaugeValue, float64(errorMetric), "mirroring")
+	ch <- prometheus.MustNewConstMetric(collectDuration, prometheus.GaugeValue, time.Since(collectTime).Seconds(), "mirroring")
+}
+
+func (c *MirroringCollector) collect() ([]AsyncMirrorGroup, []RemoteMirrorPair, map[string]string, error) {
+	var groups []AsyncMirrorGroup
+	var pairs []RemoteMirrorPair
+	var volumes []Volume
+	var thinVolumes []ThinVolume
+	// Mirroring is a licensed feature, a missing one leaves its list empty
+	responses := getResponses(c.target, []request{
+		{path: fmt.Sprintf("/devmgr/v2/storage-systems/%s/async-mirrors", c.target.Name), optional: true},
+		{path: fmt.Sprintf("/devmgr/v2/storage-systems/%s/remote-mirror-pairs", c.target.Name), optional: true},
+		{path: fmt.Sprintf("/devmgr/v2/storage-systems/%s/volumes", c.target.Name)},
+		{path: fmt.Sprintf("/devmgr/v2/storage-systems/%s/thin-volumes", c.target.Name)},
+	}, c.logger)
+	err := errors.Join(
+		responses[0].decode(&groups),
+		responses[1].decode(&pairs),
+		responses[2].decode(&volumes),
+		responses[3].decode(&thinVolumes),
+	)
+	return groups, pairs, addThinVolumeLabels(volumeLabels(volumes), thinVolumes), err
+}
@@ -0,0 +1,189 @@
+package collector
+
+import (
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/sckyzo/eseries_exporter/internal/config"
+)
+
+func TestMirroringCollector(t *testing.T) {
+	timeNow = func() time.Time { return time.Unix(1700000600, 0) }
+	defer func() { timeNow = time.Now }()
+	fixtures := make(map[string][]byte)
+	for path, file := range map[string]string{
+		"async-mirrors":       "testdata/async-mirrors.json",
+		"remote-mirror-pairs": "testdata/remote-mirror-pairs.json",
+		"volumes":             "testdata/volumes-response.json",
+		"thin-volumes":        "testdata/thin-volumes-response.json",
+	} {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatalf("Error loading fixture data: %s", err.Error())
+		}
+		fixtures[path] = data
+	}
+	expected := `# HELP eseries_async_mirror_group_role Role of this storage system in the async mirror group, value is always 1
+# TYPE eseries_async_mirror_group_role gauge
+eseries_async_mirror_group_role{group="AMG_DB",remote_system="dr-array",role="primary"} 1
+eseries_async_mirror_group_role{group="AMG_FILES",remote_system="dr-array",role="primary"} 1
+# HELP eseries_async_mirror_group_status Status of the async mirror group (1 for optimal, 0 otherwise)
+# TYPE eseries_async_mirror_group_status gauge
+eseries_async_mirror_group_status{group="AMG_DB",remote_system="dr-array",status="optimal"} 1
+eseries_async_mirror_group_status{group="AMG_FILES",remote_system="dr-array",status="failed"} 0
+# HELP eseries_async_mirror_group_sync_state Synchronization activity of the async mirror group (1 for active or idle, 0 otherwise)
+# TYPE eseries_async_mirror_group_sync_state gauge
+eseries_async_mirror_group_sync_state{group="AMG_DB",remote_system="dr-array",state="idle"} 1
+eseries_async_mirror_group_sync_state{group="AMG_FILES",remote_system="dr-array",state="paused"} 0
+# HELP eseries_async_mirror_group_last_sync_timestamp_seconds Time of the last successful synchronization, the current recovery point
+# TYPE eseries_async_mirror_group_last_sync_timestamp_seconds gauge
+eseries_async_mirror_group_last_sync_timestamp_seconds{group="AMG_DB",remote_system="dr-array"} 1.7e+09
+# HELP eseries_async_mirror_group_recovery_point_age_seconds Age of the current recovery point in seconds
+# TYPE eseries_async_mirror_group_recovery_point_age_seconds gauge
+eseries_async_mirror_group_recovery_point_age_seconds{group="AMG_DB",remote_system="dr-array"} 600
+# HELP eseries_async_mirror_group_recovery_point_age_alert_threshold_seconds Recovery point age in seconds at which the array raises an alert
+# TYPE eseries_async_mirror_group_recovery_point_age_alert_threshold_seconds gauge
+eseries_async_mirror_group_recovery_point_age_alert_threshold_seconds{group="AMG_DB",remote_system="dr-array"} 1200
+eseries_async_mirror_group_recovery_point_age_alert_threshold_seconds{group="AMG_FILES",remote_system="dr-array"} 7200
+# HELP eseries_async_mirror_group_link_up Whether the mirror link to the remote storage system is connected (1) or not (0)
+# TYPE eseries_async_mirror_group_link_up gauge
+eseries_async_mirror_group_link_up{group="AMG_DB",remote_system="dr-array"} 1
+eseries_async_mirror_group_link_up{group="AMG_FILES",remote_system="dr-array"} 0
+# HELP eseries_sync_mirror_role Role of the local volume in the synchronous mirror pair, value is always 1
+# TYPE eseries_sync_mirror_role gauge
+eseries_sync_mirror_role{remote_system="metro-array",remote_volume="Thin_1_mirror",role="secondary",volume="Thin_1"} 1
+eseries_sync_mirror_role{remote_system="metro-array",remote_volume="Volume_1_mirror",role="primary",volume="Volume_1"} 1
+# HELP eseries_sync_mirror_sync_state Synchronization state of the synchronous mirror pair (1 for optimal, 0 otherwise)
+# TYPE eseries_sync_mirror_sync_state gauge
+eseries_sync_mirror_sync_state{remote_system="metro-array",remote_volume="Thin_1_mirror",state="optimal",volume="Thin_1"} 1
+eseries_sync_mirror_sync_state{remote_system="metro-array",remote_volume="Volume_1_mirror",state="optimal",volume="Volume_1"} 1
+# HELP eseries_sync_mirror_link_up Whether the mirror link to the remote storage system is connected (1) or not (0)
+# TYPE eseries_sync_mirror_link_up gauge
+eseries_sync_mirror_link_up{remote_system="metro-array",remote_volume="Thin_1_mirror",volume="Thin_1"} 1
+eseries_sync_mirror_link_up{remote_system="metro-array",remote_volume="Volume_1_mirror",volume="Volume_1"} 1
+# HELP eseries_exporter_collect_error Indicates if error has occurred during collection
+# TYPE eseries_exporter_collect_error gauge
+eseries_exporter_collect_error{collector="mirroring"} 0
+`
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		path := strings.TrimPrefix(req.URL.Path, "/devmgr/v2/storage-systems/test/")
+		data, ok := fixtures[path]
+		if !ok {
+			http.Error(rw, "not found", http.StatusNotFound)
+			return
+		}
+		_, _ = rw.Write(data)
+	}))
+	defer server.Close()
+	baseURL, _ := url.Parse(server.URL)
+	target := config.Target{
+		Name:       "test",
+		User:       "test",
+		Password:   "test",
+		BaseURL:    baseURL,
+		HttpClient: &http.Client{},
+	}
+	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+	collector := NewMirroringExporter(target, logger)
+	gatherers := setupGatherer(collector)
+	if val, err := testutil.GatherAndCount(gatherers); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	} else if val != 20 {
+		t.Errorf("Unexpected collection count %d, expected 20", val)
+	}
+	if err := testutil.GatherAndCompare(gatherers, strings.NewReader(expected),
+		"eseries_async_mirror_group_role", "eseries_async_mirror_group_status", "eseries_async_mirror_group_sync_state",
+		"eseries_async_mirror_group_last_sync_timestamp_seconds", "eseries_async_mirror_group_recovery_point_age_seconds",
+		"eseries_async_mirror_group_recovery_point_age_alert_threshold_seconds", "eseries_async_mirror_group_link_up",
+		"eseries_sync_mirror_role", "eseries_sync_mirror_sync_state", "eseries_sync_mirror_link_up",
+		"eseries_exporter_collect_error"); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+}
+
+func TestMirroringCollectorAsyncOnly(t *testing.T) {
+	// Without the synchronous mirroring feature its endpoint answers 404
+	fixtures := make(map[string][]byte)
+	for path, file := range map[string]string{
+		"async-mirrors": "testdata/async-mirrors.json",
+		"volumes":       "testdata/volumes-response.json",
+		"thin-volumes":  "testdata/thin-volumes-response.json",
+	} {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatalf("Error loading fixture data: %s", err.Error())
+		}
+		fixtures[path] = data
+	}
+	expected := `# HELP eseries_async_mirror_group_role Role of this storage system in the async mirror group, value is always 1
+# TYPE eseries_async_mirror_group_role gauge
+eseries_async_mirror_group_role{group="AMG_DB",remote_system="dr-array",role="primary"} 1
+eseries_async_mirror_group_role{group="AMG_FILES",remote_system="dr-array",role="primary"} 1
+# HELP eseries_exporter_collect_error Indicates if error has occurred during collection
+# TYPE eseries_exporter_collect_error gauge
+eseries_exporter_collect_error{collector="mirroring"} 0
+`
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		data, ok := fixtures[strings.TrimPrefix(req.URL.Path, "/devmgr/v2/storage-systems/test/")]
+		if !ok {
+			http.Error(rw, "not found", http.StatusNotFound)
+			return
+		}
+		_, _ = rw.Write(data)
+	}))
+	defer server.Close()
+	baseURL, _ := url.Parse(server.URL)
+	target := config.Target{
+		Name:       "test",
+		User:       "test",
+		Password:   "test",
+		BaseURL:    baseURL,
+		HttpClient: &http.Client{},
+	}
+	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+	collector := NewMirroringExporter(target, logger)
+	gatherers := setupGatherer(collector)
+	if err := testutil.GatherAndCompare(gatherers, strings.NewReader(expected),
+		"eseries_async_mirror_group_role", "eseries_sync_mirror_role", "eseries_exporter_collect_error"); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+}
+
+func TestMirroringCollectorError(t *testing.T) {
+	expected := `# HELP eseries_exporter_collect_error Indicates if error has occurred during collection
+# TYPE eseries_exporter_collect_error gauge
+eseries_exporter_collect_error{collector="mirroring"} 1
+`
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		http.Error(rw, "error", http.StatusNotFound)
+	}))
+	defer server.Close()
+	baseURL, _ := url.Parse(server.URL)
+	target := config.Target{
+		Name:       "test",
+		User:       "test",
+		Password:   "test",
+		BaseURL:    baseURL,
+		HttpClient: &http.Client{},
+	}
+	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+	collector := NewMirroringExporter(target, logger)
+	gatherers := setupGatherer(collector)
+	if val, err := testutil.GatherAndCount(gatherers); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	} else if val != 2 {
+		t.Errorf("Unexpected collection count %d, expected 2", val)
+	}
+	if err := testutil.GatherAndCompare(gatherers, strings.NewReader(expected),
+		"eseries_async_mirror_group_status", "eseries_exporter_collect_error"); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+}
@@ -0,0 +1,100 @@
+package collector
+
+import (
+	"bytes"
+	"io"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+)
+
+var (
+	// openMetricsStateSets maps one-hot status families, which export every known
+	// status with 1 for the current one, to the label holding the status.
+	openMetricsStateSets = map[string]string{
+		prometheus.BuildFQName(namespace, "storage_system", "status"): "status",
+	}
+	// openMetricsInfos lists identity families whose value is always 1.
+	openMetricsInfos = map[string]bool{
+		prometheus.BuildFQName(namespace, "host", "info"):           true,
+		prometheus.BuildFQName(namespace, "volume_mapping", "info"): true,
+	}
+	// openMetricsUnits are the unit suffixes declared with # UNIT, longest first.
+	openMetricsUnits = []string{"bytes_per_second", "seconds", "bytes", "ratio", "celsius", "watts", "rpm"}
+)
+
+func init() {
+	for _, component := range append([]StatusComponent{driveComponent}, hardwareInventoryComponents...) {
+		openMetricsStateSets[prometheus.BuildFQName(namespace, component.Name, "status")] = "status"
+	}
+}
+
+// WriteOpenMetrics encodes families in the OpenMetrics text format. One-hot status
+// families are exposed as StateSets, identity families as Info and families named
+// after a unit declare it. expfmt has neither StateSet nor Info, so those families
+// are encoded as gauges and their metadata rewritten.
+func WriteOpenMetrics(w io.Writer, families []*dto.MetricFamily) error {
+	for _, family := range families {
+		if err := writeOpenMetricsFamily(w, family); err != nil {
+			return err
+		}
+	}
+	_, err := expfmt.FinalizeOpenMetrics(w)
+	return err
+}
+
+func writeOpenMetricsFamily(w io.Writer, family *dto.MetricFamily) error {
+	name := family.GetName()
+	if family.GetType() == dto.MetricType_GAUGE {
+		// The state label of a StateSet is named after the family
+		if label, ok := openMetricsStateSets[name]; ok {
+			for _, m := range family.Metric {
+				for _, l := range m.Label {
+					if l.GetName() == label {
+						l.Name = &name
+					}
+				}
+			}
+			return writeOpenMetricsRetyped(w, family, name, "stateset")
+		}
+		if openMetricsInfos[name] {
+			return writeOpenMetricsRetyped(w, family, strings.TrimSuffix(name, "_info"), "info")
+		}
+	}
+	if unit := openMetricsUnit(family); unit != "" {
+		family.Unit = &unit
+	}
+	_, err := expfmt.MetricFamilyToOpenMetrics(w, family, expfmt.WithCreatedLines(), expfmt.WithUnit())
+	return err
+}
+
+// writeOpenMetricsRetyped encodes a gauge family and rewrites its HELP and TYPE
+// lines to metadataName and metricType.
+func writeOpenMetricsRetyped(w io.Writer, family *dto.MetricFamily, metadataName string, metricType string) error {
+	var buf bytes.Buffer
+	if _, err := expfmt.MetricFamilyToOpenMetrics(&buf, family); err != nil {
+		return err
+	}
+	name := family.GetName()
+	out := bytes.Replace(buf.Bytes(), []byte("# HELP "+name+" "), []byte("# HELP "+metadataName+" "), 1)
+	out = bytes.Replace(out, []byte("# TYPE "+name+" gauge\n"), []byte("# TYPE "+metadataName+" "+metricType+"\n"), 1)
+	_, err := w.Write(out)
+	return err
+}
+
+// openMetricsUnit returns the unit the family name ends with, ignoring the _total
+// suffix of counters.
+func openMetricsUnit(family *dto.MetricFamily) string {
+	name := family.GetName()
+	if family.GetType() == dto.MetricType_COUNTER {
+		name = strings.TrimSuffix(name, "_total")
+	}
+	for _, unit := range openMetricsUnits {
+		if strings.HasSuffix(name, "_"+unit) {
+			return unit
+		}
+	}
+	return ""
+}
@@ -0,0 +1,66 @@
+package collector
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestWriteOpenMetrics(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	status := prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: "eseries_drive_status", Help: "Status of drive hardware device"},
+		[]string{"tray", "slot", "status"})
+	status.WithLabelValues("0", "1", "optimal").Set(1)
+	status.WithLabelValues("0", "1", "failed").Set(0)
+	info := prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: "eseries_host_info", Help: "Host definition"},
+		[]string{"host", "host_group", "host_type"})
+	info.WithLabelValues("node01", "cluster", "linux").Set(1)
+	readBytes := prometheus.NewCounterVec(prometheus.CounterOpts{Name: "eseries_drive_read_bytes_total", Help: "Drive statistic readBytes"},
+		[]string{"tray", "slot"})
+	readBytes.WithLabelValues("0", "1").Add(1024)
+	throughput := prometheus.NewGauge(prometheus.GaugeOpts{Name: "eseries_system_read_throughput_bytes_per_second", Help: "System read throughput"})
+	throughput.Set(2048)
+	iops := prometheus.NewGauge(prometheus.GaugeOpts{Name: "eseries_system_read_iops", Help: "System read IOPS"})
+	iops.Set(10)
+	registry.MustRegister(status, info, readBytes, throughput, iops)
+	families, err := registry.Gather()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	// Counters from client_golang carry a created timestamp, drop it to compare
+	for _, family := range families {
+		for _, m := range family.Metric {
+			if m.Counter != nil {
+				m.Counter.CreatedTimestamp = nil
+			}
+		}
+	}
+	expected := `# HELP eseries_drive_read_bytes Drive statistic readBytes
+# TYPE eseries_drive_read_bytes counter
+# UNIT eseries_drive_read_bytes bytes
+eseries_drive_read_bytes_total{slot="1",tray="0"} 1024.0
+# HELP eseries_drive_status Status of drive hardware device
+# TYPE eseries_drive_status stateset
+eseries_drive_status{slot="1",eseries_drive_status="failed",tray="0"} 0.0
+eseries_drive_status{slot="1",eseries_drive_status="optimal",tray="0"} 1.0
+# HELP eseries_host Host definition
+# TYPE eseries_host info
+eseries_host_info{host="node01",host_group="cluster",host_type="linux"} 1.0
+# HELP eseries_system_read_iops System read IOPS
+# TYPE eseries_system_read_iops gauge
+eseries_system_read_iops 10.0
+# HELP eseries_system_read_throughput_bytes_per_second System read throughput
+# TYPE eseries_system_read_throughput_bytes_per_second gauge
+# UNIT eseries_system_read_throughput_bytes_per_second bytes_per_second
+eseries_system_read_throughput_bytes_per_second 2048.0
+# EOF
+`
+	var buf bytes.Buffer
+	if err := WriteOpenMetrics(&buf, families); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if buf.String() != expected {
+		t.Errorf("Unexpected OpenMetrics output:\n%s", buf.String())
+	}
+}
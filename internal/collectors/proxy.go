@@ -0,0 +1,141 @@
+package collector
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+
+	"github.com/sckyzo/eseries_exporter/internal/config"
+)
+
+// ProxyTarget is the target value selecting every storage system of the proxy.
+const ProxyTarget = "*"
+
+var (
+	targetUp = prometheus.NewDesc(prometheus.BuildFQName(namespace, "target", "up"),
+		"Indicates if every collector succeeded for the storage system", []string{"system_id", "system_name"}, nil)
+	targetDuration = prometheus.NewDesc(prometheus.BuildFQName(namespace, "target", "collect_duration_seconds"),
+		"Time spent collecting the storage system", []string{"system_id", "system_name"}, nil)
+)
+
+// proxySystemResult holds the families gathered from one storage system.
+type proxySystemResult struct {
+	system   StorageSystem
+	families []*dto.MetricFamily
+	up       bool
+	duration time.Duration
+}
+
+// ProxyGatherer returns a gatherer running the target's collectors against every
+// storage system the proxy manages, at most target.ProxyConcurrency at a time.
+// Every series gains system_id and system_name labels.
+func ProxyGatherer(target config.Target, logger *slog.Logger) prometheus.Gatherer {
+	return prometheus.GathererFunc(func() ([]*dto.MetricFamily, error) {
+		systems, err := listStorageSystems(target, logger)
+		if err != nil {
+			return nil, err
+		}
+		concurrency := target.ProxyConcurrency
+		if concurrency <= 0 {
+			concurrency = 1
+		}
+		results := make([]proxySystemResult, len(systems))
+		sem := make(chan struct{}, concurrency)
+		wg := &sync.WaitGroup{}
+		for i, system := range systems {
+			wg.Add(1)
+			go func(i int, system StorageSystem) {
+				defer wg.Done()
+				sem <- struct{}{}
+				defer func() { <-sem }()
+				results[i] = gatherStorageSystem(target, system, logger)
+			}(i, system)
+		}
+		wg.Wait()
+
+		gatherers := prometheus.Gatherers{}
+		registry := prometheus.NewRegistry()
+		registry.MustRegister(proxyResultsCollector(results))
+		gatherers = append(gatherers, registry)
+		// A storage system failing to gather is reported by its up metric rather
+		// than failing the whole scrape
+		for _, r := range results {
+			gatherers = append(gatherers, prometheus.GathererFunc(func() ([]*dto.MetricFamily, error) {
+				return r.families, nil
+			}))
+		}
+		return gatherers.Gather()
+	})
+}
+
+func listStorageSystems(target config.Target, logger *slog.Logger) ([]StorageSystem, error) {
+	var systems []StorageSystem
+	body, err := getRequest(target, "/devmgr/v2/storage-systems", logger)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(body, &systems); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal storage systems: %w", err)
+	}
+	return systems, nil
+}
+
+func gatherStorageSystem(target config.Target, system StorageSystem, logger *slog.Logger) proxySystemResult {
+	collectTime := time.Now()
+	target.Name = system.ID
+	registry := prometheus.NewRegistry()
+	registerer := prometheus.WrapRegistererWith(prometheus.Labels{"system_id": system.ID, "system_name": system.Name}, registry)
+	for _, c := range NewCollector(target, logger).Collectors {
+		if err := registerer.Register(c); err != nil {
+			logger.Error("Collector registration failed", "target", system.ID, "error", err)
+		}
+	}
+	families, err := registry.Gather()
+	if err != nil {
+		logger.Error("Error gathering storage system", "target", system.ID, "error", err)
+	}
+	return proxySystemResult{
+		system:   system,
+		families: families,
+		up:       err == nil && !hasCollectError(families),
+		duration: time.Since(collectTime),
+	}
+}
+
+func hasCollectError(families []*dto.MetricFamily) bool {
+	for _, family := range families {
+		if family.GetName() != prometheus.BuildFQName(namespace, "exporter", "collect_error") {
+			continue
+		}
+		for _, m := range family.GetMetric() {
+			if m.GetGauge().GetValue() != 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// proxyResultsCollector exports the up and duration metrics of each storage system.
+type proxyResultsCollector []proxySystemResult
+
+func (c proxyResultsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- targetUp
+	ch <- targetDuration
+}
+
+func (c proxyResultsCollector) Collect(ch chan<- prometheus.Metric) {
+	for _, r := range c {
+		var up float64
+		if r.up {
+			up = 1
+		}
+		ch <- prometheus.MustNewConstMetric(targetUp, prometheus.GaugeValue, up, r.system.ID, r.system.Name)
+		ch <- prometheus.MustNewConstMetric(targetDuration, prometheus.GaugeValue, r.duration.Seconds(), r.system.ID, r.system.Name)
+	}
+}
@@ -0,0 +1,116 @@
+package collector
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/sckyzo/eseries_exporter/internal/config"
+)
+
+func TestProxyGatherer(t *testing.T) {
+	fixtureData, err := os.ReadFile("testdata/storage-systems.json")
+	if err != nil {
+		t.Fatalf("Error loading fixture data: %s", err.Error())
+	}
+	expected := `# HELP eseries_exporter_collect_error Indicates if error has occurred during collection
+# TYPE eseries_exporter_collect_error gauge
+eseries_exporter_collect_error{collector="storage-systems",system_id="array1",system_name="Array 1"} 0
+eseries_exporter_collect_error{collector="storage-systems",system_id="array2",system_name="Array 2"} 1
+# HELP eseries_storage_system_status Storage System status, 1=optimal 0=all other states
+# TYPE eseries_storage_system_status gauge
+eseries_storage_system_status{status="lockDown",system_id="array1",system_name="Array 1"} 0
+eseries_storage_system_status{status="needsAttn",system_id="array1",system_name="Array 1"} 0
+eseries_storage_system_status{status="neverContacted",system_id="array1",system_name="Array 1"} 0
+eseries_storage_system_status{status="newDevice",system_id="array1",system_name="Array 1"} 0
+eseries_storage_system_status{status="offline",system_id="array1",system_name="Array 1"} 0
+eseries_storage_system_status{status="optimal",system_id="array1",system_name="Array 1"} 1
+eseries_storage_system_status{status="removed",system_id="array1",system_name="Array 1"} 0
+eseries_storage_system_status{status="unknown",system_id="array1",system_name="Array 1"} 0
+# HELP eseries_target_up Indicates if every collector succeeded for the storage system
+# TYPE eseries_target_up gauge
+eseries_target_up{system_id="array1",system_name="Array 1"} 1
+eseries_target_up{system_id="array2",system_name="Array 2"} 0
+`
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/devmgr/v2/storage-systems":
+			_, _ = rw.Write([]byte(`[{"id":"array1","name":"Array 1"},{"id":"array2","name":"Array 2"}]`))
+		case "/devmgr/v2/storage-systems/array1":
+			_, _ = rw.Write(fixtureData)
+		default:
+			http.Error(rw, "not found", http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	baseURL, _ := url.Parse(server.URL)
+	target := config.Target{
+		Name:             ProxyTarget,
+		User:             "test",
+		Password:         "test",
+		BaseURL:          baseURL,
+		HttpClient:       &http.Client{},
+		Collectors:       []string{"storage-systems"},
+		ProxyConcurrency: 2,
+	}
+	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+	gatherer := ProxyGatherer(target, logger)
+	if val, err := testutil.GatherAndCount(gatherer); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	} else if val != 16 {
+		t.Errorf("Unexpected collection count %d, expected 16", val)
+	}
+	if err := testutil.GatherAndCompare(gatherer, strings.NewReader(expected),
+		"eseries_storage_system_status", "eseries_exporter_collect_error", "eseries_target_up"); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+}
+
+func TestProxyGathererConcurrency(t *testing.T) {
+	var lock sync.Mutex
+	var inFlight, maxInFlight int
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/devmgr/v2/storage-systems" {
+			_, _ = rw.Write([]byte(`[{"id":"array1"},{"id":"array2"},{"id":"array3"},{"id":"array4"}]`))
+			return
+		}
+		lock.Lock()
+		inFlight++
+		if inFlight > maxInFlight {
+			maxInFlight = inFlight
+		}
+		lock.Unlock()
+		time.Sleep(20 * time.Millisecond)
+		lock.Lock()
+		inFlight--
+		lock.Unlock()
+		_, _ = fmt.Fprintf(rw, `{"id":"%s","status":"optimal"}`, strings.TrimPrefix(req.URL.Path, "/devmgr/v2/storage-systems/"))
+	}))
+	defer server.Close()
+	baseURL, _ := url.Parse(server.URL)
+	target := config.Target{
+		Name:             ProxyTarget,
+		User:             "test",
+		Password:         "test",
+		BaseURL:          baseURL,
+		HttpClient:       &http.Client{},
+		Collectors:       []string{"storage-systems"},
+		ProxyConcurrency: 2,
+	}
+	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+	if _, err := ProxyGatherer(target, logger).Gather(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if maxInFlight != 2 {
+		t.Errorf("Unexpected concurrency %d, expected 2", maxInFlight)
+	}
+}
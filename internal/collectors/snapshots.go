@@ -0,0 +1,187 @@
+package collector
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/sckyzo/eseries_exporter/internal/config"
+)
+
+type SnapshotGroup struct {
+	ID                string `json:"id"`
+	Label             string `json:"label"`
+	BaseVolume        string `json:"baseVolume"`
+	RepositoryVolume  string `json:"repositoryVolume"`
+	Status            string `json:"status"`
+	FullWarnThreshold int    `json:"fullWarnThreshold"`
+	RepFullPolicy     string `json:"repFullPolicy"`
+}
+
+type SnapshotImage struct {
+	ID           string `json:"id"`
+	PitGroupRef  string `json:"pitGroupRef"`
+	PitTimestamp string `json:"pitTimestamp"`
+}
+
+type SnapshotRepositoryUtilization struct {
+	GroupRef               string `json:"groupRef"`
+	PitGroupBytesUsed      string `json:"pitGroupBytesUsed"`
+	PitGroupBytesAvailable string `json:"pitGroupBytesAvailable"`
+}
+
+type ConcatRepository struct {
+	ID                string `json:"id"`
+	AggregateCapacity string `json:"aggregateCapacity"`
+}
+
+type SnapshotsCollector struct {
+	RepositoryCapacity    *prometheus.Desc
+	RepositoryUsed        *prometheus.Desc
+	RepositoryUtilization *prometheus.Desc
+	FullWarnThreshold     *prometheus.Desc
+	FullPolicy            *prometheus.Desc
+	Status                *prometheus.Desc
+	Images                *prometheus.Desc
+	NewestImage           *prometheus.Desc
+	target                config.Target
+	logger                *slog.Logger
+}
+
+func init() {
+	registerCollector("snapshots", false, NewSnapshotsExporter)
+}
+
+func NewSnapshotsExporter(target config.Target, logger *slog.Logger) Collector {
+	labels := []string{"group", "base_volume"}
+	return &SnapshotsCollector{
+		RepositoryCapacity: prometheus.NewDesc(prometheus.BuildFQName(namespace, "snapshot_group", "repository_capacity_bytes"),
+			"Capacity of the snapshot group repository in bytes", labels, nil),
+		RepositoryUsed: prometheus.NewDesc(prometheus.BuildFQName(namespace, "snapshot_group", "repository_used_bytes"),
+			"Used capacity of the snapshot group repository in bytes", labels, nil),
+		RepositoryUtilization: prometheus.NewDesc(prometheus.BuildFQName(namespace, "snapshot_group", "repository_utilization_ratio"),
+			"Utilization ratio of the snapshot group repository (0-1)", labels, nil),
+		FullWarnThreshold: prometheus.NewDesc(prometheus.BuildFQName(namespace, "snapshot_group", "repository_full_warn_threshold_ratio"),
+			"Repository utilization ratio (0-1) at which the array warns", labels, nil),
+		FullPolicy: prometheus.NewDesc(prometheus.BuildFQName(namespace, "snapshot_group", "repository_full_policy"),
+			"Action taken when the repository is full, purgepit or failbasewrites", append(labels, "policy"), nil),
+		Status: prometheus.NewDesc(prometheus.BuildFQName(namespace, "snapshot_group", "status"),
+			"Status of the snapshot group (1 for optimal, 0 otherwise)", append(labels, "status"), nil),
+		Images: prometheus.NewDesc(prometheus.BuildFQName(namespace, "snapshot_group", "images"),
+			"Number of snapshot images in the snapshot group", labels, nil),
+		NewestImage: prometheus.NewDesc(prometheus.BuildFQName(namespace, "snapshot_group", "newest_image_timestamp_seconds"),
+			"Creation time of the newest snapshot image in the snapshot group", labels, nil),
+		target: target,
+		logger: logger,
+	}
+}
+
+func (c *SnapshotsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.RepositoryCapacity
+	ch <- c.RepositoryUsed
+	ch <- c.RepositoryUtilization
+	ch <- c.FullWarnThreshold
+	ch <- c.FullPolicy
+	ch <- c.Status
+	ch <- c.Images
+	ch <- c.NewestImage
+}
+
+func (c *SnapshotsCollector) Collect(ch chan<- prometheus.Metric) {
+	c.logger.Debug("Collecting snapshots metrics")
+	collectTime := time.Now()
+	var errorMetric int
+	groups, images, utilization, repositories, volumes, err := c.collect()
+	if err != nil {
+		c.logger.Error("Collection failed", "error", err)
+		errorMetric = 1
+	}
+
+	labels := volumeLabels(volumes)
+	imageCount := make(map[string]float64)
+	newestImage := make(map[string]float64)
+	for _, i := range images {
+		imageCount[i.PitGroupRef]++
+		timestamp, err := strconv.ParseFloat(i.PitTimestamp, 64)
+		if err != nil {
+			continue
+		}
+		if timestamp > newestImage[i.PitGroupRef] {
+			newestImage[i.PitGroupRef] = timestamp
+		}
+	}
+	used := make(map[string]SnapshotRepositoryUtilization)
+	for _, u := range utilization {
+		used[u.GroupRef] = u
+	}
+	capacities := make(map[string]string)
+	for _, r := range repositories {
+		capacities[r.ID] = r.AggregateCapacity
+	}
+
+	for _, g := range groups {
+		baseVolume := lookupLabel(labels, g.BaseVolume)
+		// Usage is only known from the utilization report, which may be unavailable
+		if u, ok := used[g.ID]; ok {
+			usedBytes, _ := strconv.ParseFloat(u.PitGroupBytesUsed, 64)
+			capacityBytes, err := strconv.ParseFloat(capacities[g.RepositoryVolume], 64)
+			if err != nil {
+				// Fall back to the utilization report when the repository volume is not listed
+				availableBytes, _ := strconv.ParseFloat(u.PitGroupBytesAvailable, 64)
+				capacityBytes = usedBytes + availableBytes
+			}
+			ch <- prometheus.MustNewConstMetric(c.RepositoryCapacity, prometheus.GaugeValue, capacityBytes, g.Label, baseVolume)
+			ch <- prometheus.MustNewConstMetric(c.RepositoryUsed, prometheus.GaugeValue, usedBytes, g.Label, baseVolume)
+			utilizationRatio := 0.0
+			if capacityBytes > 0 {
+				utilizationRatio = usedBytes / capacityBytes
+			}
+			ch <- prometheus.MustNewConstMetric(c.RepositoryUtilization, prometheus.GaugeValue, utilizationRatio, g.Label, baseVolume)
+		} else if capacityBytes, err := strconv.ParseFloat(capacities[g.RepositoryVolume], 64); err == nil {
+			ch <- prometheus.MustNewConstMetric(c.RepositoryCapacity, prometheus.GaugeValue, capacityBytes, g.Label, baseVolume)
+		}
+		ch <- prometheus.MustNewConstMetric(c.FullWarnThreshold, prometheus.GaugeValue, float64(g.FullWarnThreshold)/100, g.Label, baseVolume)
+		ch <- prometheus.MustNewConstMetric(c.FullPolicy, prometheus.GaugeValue, 1, g.Label, baseVolume, g.RepFullPolicy)
+		statusValue := 0.0
+		if g.Status == "optimal" {
+			statusValue = 1.0
+		}
+		ch <- prometheus.MustNewConstMetric(c.Status, prometheus.GaugeValue, statusValue, g.Label, baseVolume, g.Status)
+		ch <- prometheus.MustNewConstMetric(c.Images, prometheus.GaugeValue, imageCount[g.ID], g.Label, baseVolume)
+		if newest, ok := newestImage[g.ID]; ok {
+			ch <- prometheus.MustNewConstMetric(c.NewestImage, prometheus.GaugeValue, newest, g.Label, baseVolume)
+		}
+	}
+
+	ch <- prometheus.MustNewConstMetric(collectError, prometheus.GaugeValue, float64(errorMetric), "snapshots")
+	ch <- prometheus.MustNewConstMetric(collectDuration, prometheus.GaugeValue, time.Since(collectTime).Seconds(), "snapshots")
+}
+
+func (c *SnapshotsCollector) collect() ([]SnapshotGroup, []SnapshotImage, []SnapshotRepositoryUtilization, []ConcatRepository, []Volume, error) {
+	var groups []SnapshotGroup
+	var images []SnapshotImage
+	var utilization []SnapshotRepositoryUtilization
+	var repositories []ConcatRepository
+	var volumes []Volume
+	// Snapshots are a licensed feature, their endpoints are optional so volumes alone
+	// failing or a missing feature does not blank the others
+	responses := getResponses(c.target, []request{
+		{path: fmt.Sprintf("/devmgr/v2/storage-systems/%s/snapshot-groups", c.target.Name), optional: true},
+		{path: fmt.Sprintf("/devmgr/v2/storage-systems/%s/snapshot-images", c.target.Name), optional: true},
+		{path: fmt.Sprintf("/devmgr/v2/storage-systems/%s/snapshot-groups/repository-utilization", c.target.Name), optional: true},
+		{path: fmt.Sprintf("/devmgr/v2/storage-systems/%s/repositories/concat", c.target.Name), optional: true},
+		{path: fmt.Sprintf("/devmgr/v2/storage-systems/%s/volumes", c.target.Name)},
+	}, c.logger)
+	err := errors.Join(
+		responses[0].decode(&groups),
+		responses[1].decode(&images),
+		responses[2].decode(&utilization),
+		responses[3].decode(&repositories),
+		responses[4].decode(&volumes),
+	)
+	return groups, images, utilization, repositories, volumes, err
+}
@@ -0,0 +1,180 @@
+package collector
+
+import (
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/sckyzo/eseries_exporter/internal/config"
+)
+
+func TestSnapshotsCollector(t *testing.T) {
+	fixtures := make(map[string][]byte)
+	for path, file := range map[string]string{
+		"snapshot-groups":                        "testdata/snapshot-groups.json",
+		"snapshot-images":                        "testdata/snapshot-images.json",
+		"snapshot-groups/repository-utilization": "testdata/snapshot-repository-utilization.json",
+		"repositories/concat":                    "testdata/repositories-concat.json",
+		"volumes":                                "testdata/volumes-response.json",
+	} {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatalf("Error loading fixture data: %s", err.Error())
+		}
+		fixtures[path] = data
+	}
+	expected := `# HELP eseries_snapshot_group_images Number of snapshot images in the snapshot group
+# TYPE eseries_snapshot_group_images gauge
+eseries_snapshot_group_images{base_volume="Volume_1",group="SG_Volume_1"} 2
+eseries_snapshot_group_images{base_volume="Volume_3",group="SG_Volume_3"} 0
+# HELP eseries_snapshot_group_newest_image_timestamp_seconds Creation time of the newest snapshot image in the snapshot group
+# TYPE eseries_snapshot_group_newest_image_timestamp_seconds gauge
+eseries_snapshot_group_newest_image_timestamp_seconds{base_volume="Volume_1",group="SG_Volume_1"} 1.606435201e+09
+# HELP eseries_snapshot_group_repository_capacity_bytes Capacity of the snapshot group repository in bytes
+# TYPE eseries_snapshot_group_repository_capacity_bytes gauge
+eseries_snapshot_group_repository_capacity_bytes{base_volume="Volume_1",group="SG_Volume_1"} 1.073741824e+10
+eseries_snapshot_group_repository_capacity_bytes{base_volume="Volume_3",group="SG_Volume_3"} 1.073741824e+10
+# HELP eseries_snapshot_group_repository_full_policy Action taken when the repository is full, purgepit or failbasewrites
+# TYPE eseries_snapshot_group_repository_full_policy gauge
+eseries_snapshot_group_repository_full_policy{base_volume="Volume_1",group="SG_Volume_1",policy="purgepit"} 1
+eseries_snapshot_group_repository_full_policy{base_volume="Volume_3",group="SG_Volume_3",policy="failbasewrites"} 1
+# HELP eseries_snapshot_group_repository_used_bytes Used capacity of the snapshot group repository in bytes
+# TYPE eseries_snapshot_group_repository_used_bytes gauge
+eseries_snapshot_group_repository_used_bytes{base_volume="Volume_1",group="SG_Volume_1"} 1.610612736e+09
+eseries_snapshot_group_repository_used_bytes{base_volume="Volume_3",group="SG_Volume_3"} 1.073741824e+10
+# HELP eseries_snapshot_group_repository_utilization_ratio Utilization ratio of the snapshot group repository (0-1)
+# TYPE eseries_snapshot_group_repository_utilization_ratio gauge
+eseries_snapshot_group_repository_utilization_ratio{base_volume="Volume_1",group="SG_Volume_1"} 0.15
+eseries_snapshot_group_repository_utilization_ratio{base_volume="Volume_3",group="SG_Volume_3"} 1
+# HELP eseries_snapshot_group_status Status of the snapshot group (1 for optimal, 0 otherwise)
+# TYPE eseries_snapshot_group_status gauge
+eseries_snapshot_group_status{base_volume="Volume_1",group="SG_Volume_1",status="optimal"} 1
+eseries_snapshot_group_status{base_volume="Volume_3",group="SG_Volume_3",status="full"} 0
+# HELP eseries_exporter_collect_error Indicates if error has occurred during collection
+# TYPE eseries_exporter_collect_error gauge
+eseries_exporter_collect_error{collector="snapshots"} 0
+`
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		path := strings.TrimPrefix(req.URL.Path, "/devmgr/v2/storage-systems/test/")
+		data, ok := fixtures[path]
+		if !ok {
+			http.Error(rw, "not found", http.StatusNotFound)
+			return
+		}
+		_, _ = rw.Write(data)
+	}))
+	defer server.Close()
+	baseURL, _ := url.Parse(server.URL)
+	target := config.Target{
+		Name:       "test",
+		User:       "test",
+		Password:   "test",
+		BaseURL:    baseURL,
+		HttpClient: &http.Client{},
+	}
+	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+	collector := NewSnapshotsExporter(target, logger)
+	gatherers := setupGatherer(collector)
+	if val, err := testutil.GatherAndCount(gatherers); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	} else if val != 17 {
+		t.Errorf("Unexpected collection count %d, expected 17", val)
+	}
+	if err := testutil.GatherAndCompare(gatherers, strings.NewReader(expected),
+		"eseries_snapshot_group_images", "eseries_snapshot_group_newest_image_timestamp_seconds",
+		"eseries_snapshot_group_repository_capacity_bytes", "eseries_snapshot_group_repository_full_policy",
+		"eseries_snapshot_group_repository_used_bytes", "eseries_snapshot_group_repository_utilization_ratio",
+		"eseries_snapshot_group_status", "eseries_exporter_collect_error"); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+}
+
+func TestSnapshotsCollectorPartial(t *testing.T) {
+	// A failing optional endpoint is reported without blanking the other metrics
+	fixtures := make(map[string][]byte)
+	for path, file := range map[string]string{
+		"snapshot-groups": "testdata/snapshot-groups.json",
+		"volumes":         "testdata/volumes-response.json",
+	} {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatalf("Error loading fixture data: %s", err.Error())
+		}
+		fixtures[path] = data
+	}
+	expected := `# HELP eseries_snapshot_group_status Status of the snapshot group (1 for optimal, 0 otherwise)
+# TYPE eseries_snapshot_group_status gauge
+eseries_snapshot_group_status{base_volume="Volume_1",group="SG_Volume_1",status="optimal"} 1
+eseries_snapshot_group_status{base_volume="Volume_3",group="SG_Volume_3",status="full"} 0
+# HELP eseries_exporter_collect_error Indicates if error has occurred during collection
+# TYPE eseries_exporter_collect_error gauge
+eseries_exporter_collect_error{collector="snapshots"} 1
+`
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		path := strings.TrimPrefix(req.URL.Path, "/devmgr/v2/storage-systems/test/")
+		if path == "snapshot-groups/repository-utilization" {
+			http.Error(rw, "error", http.StatusInternalServerError)
+			return
+		}
+		data, ok := fixtures[path]
+		if !ok {
+			http.Error(rw, "not found", http.StatusNotFound)
+			return
+		}
+		_, _ = rw.Write(data)
+	}))
+	defer server.Close()
+	baseURL, _ := url.Parse(server.URL)
+	target := config.Target{
+		Name:       "test",
+		User:       "test",
+		Password:   "test",
+		BaseURL:    baseURL,
+		HttpClient: &http.Client{},
+	}
+	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+	collector := NewSnapshotsExporter(target, logger)
+	gatherers := setupGatherer(collector)
+	if err := testutil.GatherAndCompare(gatherers, strings.NewReader(expected),
+		"eseries_snapshot_group_status", "eseries_snapshot_group_repository_used_bytes",
+		"eseries_exporter_collect_error"); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+}
+
+func TestSnapshotsCollectorError(t *testing.T) {
+	expected := `# HELP eseries_exporter_collect_error Indicates if error has occurred during collection
+# TYPE eseries_exporter_collect_error gauge
+eseries_exporter_collect_error{collector="snapshots"} 1
+`
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		http.Error(rw, "error", http.StatusNotFound)
+	}))
+	defer server.Close()
+	baseURL, _ := url.Parse(server.URL)
+	target := config.Target{
+		Name:       "test",
+		User:       "test",
+		Password:   "test",
+		BaseURL:    baseURL,
+		HttpClient: &http.Client{},
+	}
+	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+	collector := NewSnapshotsExporter(target, logger)
+	gatherers := setupGatherer(collector)
+	if val, err := testutil.GatherAndCount(gatherers); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	} else if val != 2 {
+		t.Errorf("Unexpected collection count %d, expected 2", val)
+	}
+	if err := testutil.GatherAndCompare(gatherers, strings.NewReader(expected),
+		"eseries_snapshot_group_status", "eseries_exporter_collect_error"); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+}
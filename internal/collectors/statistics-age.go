@@ -0,0 +1,58 @@
+package collector
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/sckyzo/eseries_exporter/internal/config"
+)
+
+var statisticsAge = prometheus.NewDesc(
+	prometheus.BuildFQName(namespace, "statistics", "age_seconds"),
+	"Age of the oldest analysed statistics returned by the proxy",
+	[]string{"collector"}, nil)
+
+// statisticsObservations tracks the observation times of the analysed statistics
+// seen by one collection. The proxy computes them over a window ending at
+// observedTimeInMS, which may lag the scrape.
+type statisticsObservations struct {
+	target config.Target
+	oldest time.Time
+}
+
+func newStatisticsObservations(target config.Target) *statisticsObservations {
+	return &statisticsObservations{target: target}
+}
+
+// observe parses observedTimeInMS and reports whether the statistics are recent
+// enough to export under the target's maximum age.
+func (o *statisticsObservations) observe(observedTimeInMS string) (time.Time, bool) {
+	observed := parseTimeMS(observedTimeInMS)
+	if observed.IsZero() {
+		return observed, true
+	}
+	if o.oldest.IsZero() || observed.Before(o.oldest) {
+		o.oldest = observed
+	}
+	if o.target.StatisticsMaxAge > 0 && timeNow().Sub(observed) > o.target.StatisticsMaxAge {
+		return observed, false
+	}
+	return observed, true
+}
+
+// metric stamps m with observed when the target opted into statistics timestamps.
+func (o *statisticsObservations) metric(observed time.Time, m prometheus.Metric) prometheus.Metric {
+	if !o.target.StatisticsTimestamps || observed.IsZero() {
+		return m
+	}
+	return prometheus.NewMetricWithTimestamp(observed, m)
+}
+
+// collect exports the age of the oldest observation, if statistics reported one.
+func (o *statisticsObservations) collect(ch chan<- prometheus.Metric, collector string) {
+	if o.oldest.IsZero() {
+		return
+	}
+	ch <- prometheus.MustNewConstMetric(statisticsAge, prometheus.GaugeValue, timeNow().Sub(o.oldest).Seconds(), collector)
+}
@@ -0,0 +1,95 @@
+package collector
+
+import (
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/sckyzo/eseries_exporter/internal/config"
+)
+
+func statisticsAgeTarget(t *testing.T) (config.Target, func()) {
+	fixtureData, err := os.ReadFile("testdata/system-statistics.json")
+	if err != nil {
+		t.Fatalf("Error loading fixture data: %s", err.Error())
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = rw.Write(fixtureData)
+	}))
+	baseURL, _ := url.Parse(server.URL)
+	target := config.Target{
+		Name:       "test",
+		User:       "test",
+		Password:   "test",
+		BaseURL:    baseURL,
+		HttpClient: &http.Client{},
+	}
+	return target, server.Close
+}
+
+func TestStatisticsTimestamps(t *testing.T) {
+	// The fixture was observed at 1585833664
+	timeNow = func() time.Time { return time.Unix(1585833694, 0) }
+	defer func() { timeNow = time.Now }()
+	target, closeServer := statisticsAgeTarget(t)
+	defer closeServer()
+	target.StatisticsTimestamps = true
+	expected := `# HELP eseries_statistics_age_seconds Age of the oldest analysed statistics returned by the proxy
+# TYPE eseries_statistics_age_seconds gauge
+eseries_statistics_age_seconds{collector="system-statistics"} 30
+`
+	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(NewSystemStatisticsExporter(target, logger))
+	families, err := registry.Gather()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for _, family := range families {
+		if !strings.HasPrefix(family.GetName(), "eseries_system_") {
+			continue
+		}
+		for _, m := range family.GetMetric() {
+			if m.GetTimestampMs() != 1585833664000 {
+				t.Errorf("Unexpected timestamp %d on %s, expected 1585833664000", m.GetTimestampMs(), family.GetName())
+			}
+		}
+	}
+	if err := testutil.GatherAndCompare(registry, strings.NewReader(expected), "eseries_statistics_age_seconds"); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+}
+
+func TestStatisticsMaxAge(t *testing.T) {
+	timeNow = func() time.Time { return time.Unix(1585833724, 0) }
+	defer func() { timeNow = time.Now }()
+	target, closeServer := statisticsAgeTarget(t)
+	defer closeServer()
+	target.StatisticsMaxAge = 30 * time.Second
+	expected := `# HELP eseries_exporter_collect_error Indicates if error has occurred during collection
+# TYPE eseries_exporter_collect_error gauge
+eseries_exporter_collect_error{collector="system-statistics"} 0
+# HELP eseries_statistics_age_seconds Age of the oldest analysed statistics returned by the proxy
+# TYPE eseries_statistics_age_seconds gauge
+eseries_statistics_age_seconds{collector="system-statistics"} 60
+`
+	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+	gatherers := setupGatherer(NewSystemStatisticsExporter(target, logger))
+	if val, err := testutil.GatherAndCount(gatherers); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	} else if val != 3 {
+		t.Errorf("Unexpected collection count %d, expected 3", val)
+	}
+	if err := testutil.GatherAndCompare(gatherers, strings.NewReader(expected),
+		"eseries_statistics_age_seconds", "eseries_exporter_collect_error"); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+}
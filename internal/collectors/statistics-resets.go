@@ -0,0 +1,61 @@
+package collector
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/sckyzo/eseries_exporter/internal/config"
+)
+
+var statisticsResets = &resetTracker{
+	resetTimes: make(map[string]int64),
+	resets:     make(map[string]float64),
+}
+
+// resetTracker remembers the last statistics reset time seen per object, keyed
+// by target, object type and object ID, so resets on the array are counted
+// across scrapes.
+type resetTracker struct {
+	sync.Mutex
+	resetTimes map[string]int64
+	resets     map[string]float64
+}
+
+// observe records resetTime for key and returns the number of resets seen for
+// key since the exporter started. The first observation is not a reset.
+func (t *resetTracker) observe(key string, resetTime time.Time) float64 {
+	t.Lock()
+	defer t.Unlock()
+	ms := resetTime.UnixMilli()
+	if last, ok := t.resetTimes[key]; ok && ms != last {
+		t.resets[key]++
+	}
+	t.resetTimes[key] = ms
+	return t.resets[key]
+}
+
+// prune forgets the keys starting with prefix that are not in seen, such as
+// replaced drives, so the tracker does not grow with objects the array dropped.
+func (t *resetTracker) prune(prefix string, seen map[string]bool) {
+	t.Lock()
+	defer t.Unlock()
+	for key := range t.resetTimes {
+		if strings.HasPrefix(key, prefix) && !seen[key] {
+			delete(t.resetTimes, key)
+			delete(t.resets, key)
+		}
+	}
+}
+
+// newCounter returns a counter metric for a monotonic total since the last reset.
+// When the output supports it the counter carries created, rendered as a _created
+// sample in OpenMetrics and the start time in OTLP, so consumers see resets.
+func newCounter(target config.Target, desc *prometheus.Desc, value float64, created time.Time, labelValues ...string) prometheus.Metric {
+	if target.CreatedTimestamps && !created.IsZero() {
+		return prometheus.MustNewConstMetricWithCreatedTimestamp(desc, prometheus.CounterValue, value, created, labelValues...)
+	}
+	return prometheus.MustNewConstMetric(desc, prometheus.CounterValue, value, labelValues...)
+}
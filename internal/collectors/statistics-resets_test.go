@@ -0,0 +1,116 @@
+package collector
+
+import (
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+
+	"github.com/sckyzo/eseries_exporter/internal/config"
+)
+
+func TestResetTrackerObserve(t *testing.T) {
+	tracker := &resetTracker{resetTimes: make(map[string]int64), resets: make(map[string]float64)}
+	first := time.Unix(1604971911, 0)
+	if resets := tracker.observe("test/1", first); resets != 0 {
+		t.Errorf("Unexpected resets %v on first observation, expected 0", resets)
+	}
+	if resets := tracker.observe("test/1", first); resets != 0 {
+		t.Errorf("Unexpected resets %v for unchanged reset time, expected 0", resets)
+	}
+	if resets := tracker.observe("test/1", first.Add(time.Hour)); resets != 1 {
+		t.Errorf("Unexpected resets %v after reset, expected 1", resets)
+	}
+	if resets := tracker.observe("test/2", first); resets != 0 {
+		t.Errorf("Unexpected resets %v for other key, expected 0", resets)
+	}
+}
+
+func TestResetTrackerPrune(t *testing.T) {
+	tracker := &resetTracker{resetTimes: make(map[string]int64), resets: make(map[string]float64)}
+	first := time.Unix(1604971911, 0)
+	for _, key := range []string{"test/drive/1", "test/drive/2", "test/controller/1", "other/drive/2"} {
+		tracker.observe(key, first)
+		tracker.observe(key, first.Add(time.Hour))
+	}
+	tracker.prune("test/drive/", map[string]bool{"test/drive/1": true})
+	for _, key := range []string{"test/drive/1", "test/controller/1", "other/drive/2"} {
+		if _, ok := tracker.resetTimes[key]; !ok || tracker.resets[key] != 1 {
+			t.Errorf("Expected %s to be kept", key)
+		}
+	}
+	if _, ok := tracker.resetTimes["test/drive/2"]; ok {
+		t.Errorf("Expected reset time of test/drive/2 to be pruned")
+	}
+	if _, ok := tracker.resets["test/drive/2"]; ok {
+		t.Errorf("Expected resets of test/drive/2 to be pruned")
+	}
+}
+
+func TestControllerStatisticsCollectorOpenMetrics(t *testing.T) {
+	fixtures := make(map[string][]byte)
+	for path, file := range map[string]string{
+		"hardware-inventory":             "testdata/controllers.json",
+		"analyzed/controller-statistics": "testdata/analysed-controller-statistics.json",
+		"controller-statistics":          "testdata/controller-statistics.json",
+	} {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatalf("Error loading fixture data: %s", err.Error())
+		}
+		fixtures[path] = data
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = rw.Write(fixtures[strings.TrimPrefix(req.URL.Path, "/devmgr/v2/storage-systems/test/")])
+	}))
+	defer server.Close()
+	baseURL, _ := url.Parse(server.URL)
+	target := config.Target{
+		Name:              "test",
+		User:              "test",
+		Password:          "test",
+		BaseURL:           baseURL,
+		HttpClient:        &http.Client{},
+		OpenMetrics:       true,
+		CreatedTimestamps: true,
+	}
+	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(NewControllerStatisticsExporter(target, logger))
+	families, err := registry.Gather()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	var found bool
+	for _, family := range families {
+		switch family.GetName() {
+		case "eseries_controller_last_reset_timestamp_seconds":
+			t.Errorf("Unexpected %s with OpenMetrics", family.GetName())
+		case "eseries_controller_max_possible_iops", "eseries_controller_max_possible_throughput_bytes_per_second":
+			if family.GetType() != dto.MetricType_GAUGE {
+				t.Errorf("Unexpected type %s for %s, expected gauge", family.GetType(), family.GetName())
+			}
+		case "eseries_controller_iops_total":
+			found = true
+			for _, m := range family.GetMetric() {
+				created := m.GetCounter().GetCreatedTimestamp()
+				if created == nil {
+					t.Fatalf("Missing created timestamp on %s", family.GetName())
+				}
+				if created.GetSeconds() != 1604971911 && created.GetSeconds() != 1604971912 {
+					t.Errorf("Unexpected created timestamp %v", created.AsTime())
+				}
+			}
+		}
+	}
+	if !found {
+		t.Errorf("Missing eseries_controller_iops_total")
+	}
+}
@@ -23,6 +23,19 @@ type StoragePool struct {
 	State            string `json:"state"`
 	DiskPool         bool   `json:"diskPool"`
 	Offline          bool   `json:"offline"`
+	VolumeGroupData  struct {
+		Type         string        `json:"type"`
+		DiskPoolData *DiskPoolData `json:"diskPoolData"`
+	} `json:"volumeGroupData"`
+}
+
+// DiskPoolData is only reported for dynamic disk pools (DDP).
+type DiskPoolData struct {
+	ReconstructionReservedDriveCount        int    `json:"reconstructionReservedDriveCount"`
+	ReconstructionReservedDriveCountCurrent int    `json:"reconstructionReservedDriveCountCurrent"`
+	ReconstructionReservedAmt               string `json:"reconstructionReservedAmt"`
+	PoolUtilizationWarningThreshold         int    `json:"poolUtilizationWarningThreshold"`
+	PoolUtilizationCriticalThreshold        int    `json:"poolUtilizationCriticalThreshold"`
 }
 
 type StoragePoolsCollector struct {
@@ -36,6 +49,12 @@ type StoragePoolsCollector struct {
 	status           *prometheus.Desc
 	state            *prometheus.Desc
 	offline          *prometheus.Desc
+
+	reservedDrives          *prometheus.Desc
+	reservedDrivesAvailable *prometheus.Desc
+	reservedBytes           *prometheus.Desc
+	warningThreshold        *prometheus.Desc
+	criticalThreshold       *prometheus.Desc
 }
 
 func init() {
@@ -84,6 +103,31 @@ func NewStoragePoolsExporter(target config.Target, logger *slog.Logger) Collecto
 			"Whether the pool is offline (1) or online (0)",
 			[]string{"pool", "raid_level"}, nil,
 		),
+		reservedDrives: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "pool", "reconstruction_reserved_drives"),
+			"Number of drives worth of capacity the disk pool reserves for reconstruction",
+			[]string{"pool", "raid_level"}, nil,
+		),
+		reservedDrivesAvailable: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "pool", "reconstruction_reserved_drives_available"),
+			"Number of drive failures the disk pool reserved capacity can still absorb",
+			[]string{"pool", "raid_level"}, nil,
+		),
+		reservedBytes: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "pool", "reconstruction_reserved_bytes"),
+			"Preservation capacity of the disk pool reserved for reconstruction in bytes",
+			[]string{"pool", "raid_level"}, nil,
+		),
+		warningThreshold: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "pool", "utilization_warning_threshold_ratio"),
+			"Utilization ratio (0-1) at which the array raises a disk pool warning",
+			[]string{"pool", "raid_level"}, nil,
+		),
+		criticalThreshold: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "pool", "utilization_critical_threshold_ratio"),
+			"Utilization ratio (0-1) at which the array raises a disk pool critical alert",
+			[]string{"pool", "raid_level"}, nil,
+		),
 	}
 }
 
@@ -95,6 +139,11 @@ func (c *StoragePoolsCollector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- c.status
 	ch <- c.state
 	ch <- c.offline
+	ch <- c.reservedDrives
+	ch <- c.reservedDrivesAvailable
+	ch <- c.reservedBytes
+	ch <- c.warningThreshold
+	ch <- c.criticalThreshold
 }
 
 func (c *StoragePoolsCollector) Collect(ch chan<- prometheus.Metric) {
@@ -186,6 +235,48 @@ func (c *StoragePoolsCollector) Collect(ch chan<- prometheus.Metric) {
 			offlineValue,
 			pool.Label, pool.RaidLevel,
 		)
+
+		// Reserved capacity and thresholds only exist for disk pools
+		ddp := pool.VolumeGroupData.DiskPoolData
+		if ddp == nil {
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(
+			c.reservedDrives,
+			prometheus.GaugeValue,
+			float64(ddp.ReconstructionReservedDriveCount),
+			pool.Label, pool.RaidLevel,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			c.reservedDrivesAvailable,
+			prometheus.GaugeValue,
+			float64(ddp.ReconstructionReservedDriveCountCurrent),
+			pool.Label, pool.RaidLevel,
+		)
+		reservedCapacity, _ := strconv.ParseFloat(ddp.ReconstructionReservedAmt, 64)
+		ch <- prometheus.MustNewConstMetric(
+			c.reservedBytes,
+			prometheus.GaugeValue,
+			reservedCapacity,
+			pool.Label, pool.RaidLevel,
+		)
+		// A threshold of 0 means the array does not raise that alert
+		if ddp.PoolUtilizationWarningThreshold > 0 {
+			ch <- prometheus.MustNewConstMetric(
+				c.warningThreshold,
+				prometheus.GaugeValue,
+				float64(ddp.PoolUtilizationWarningThreshold)/100,
+				pool.Label, pool.RaidLevel,
+			)
+		}
+		if ddp.PoolUtilizationCriticalThreshold > 0 {
+			ch <- prometheus.MustNewConstMetric(
+				c.criticalThreshold,
+				prometheus.GaugeValue,
+				float64(ddp.PoolUtilizationCriticalThreshold)/100,
+				pool.Label, pool.RaidLevel,
+			)
+		}
 	}
 }
 
@@ -32,7 +32,7 @@ func TestStoragePoolsCollector(t *testing.T) {
 	collector := NewStoragePoolsExporter(target, logger)
 
 	// Test metrics collection
-	expectedMetrics := 14 // 2 pools * 7 metrics each
+	expectedMetrics := 17 // 2 pools * 7 metrics each, plus 3 disk pool metrics, thresholds are disabled
 	count := testutil.CollectAndCount(collector)
 	if count != expectedMetrics {
 		t.Errorf("Expected %d metrics, got %d", expectedMetrics, count)
@@ -52,6 +52,15 @@ func TestStoragePoolsCollector(t *testing.T) {
 		# TYPE eseries_pool_offline gauge
 		eseries_pool_offline{pool="Pool_1",raid_level="raidDiskPool"} 0
 		eseries_pool_offline{pool="Pool_2",raid_level="raid5"} 0
+		# HELP eseries_pool_reconstruction_reserved_bytes Preservation capacity of the disk pool reserved for reconstruction in bytes
+		# TYPE eseries_pool_reconstruction_reserved_bytes gauge
+		eseries_pool_reconstruction_reserved_bytes{pool="Pool_1",raid_level="raidDiskPool"} 1.1750493650944e+13
+		# HELP eseries_pool_reconstruction_reserved_drives Number of drives worth of capacity the disk pool reserves for reconstruction
+		# TYPE eseries_pool_reconstruction_reserved_drives gauge
+		eseries_pool_reconstruction_reserved_drives{pool="Pool_1",raid_level="raidDiskPool"} 1
+		# HELP eseries_pool_reconstruction_reserved_drives_available Number of drive failures the disk pool reserved capacity can still absorb
+		# TYPE eseries_pool_reconstruction_reserved_drives_available gauge
+		eseries_pool_reconstruction_reserved_drives_available{pool="Pool_1",raid_level="raidDiskPool"} 1
 		# HELP eseries_pool_state Current state of the pool (1 for complete, 0 otherwise)
 		# TYPE eseries_pool_state gauge
 		eseries_pool_state{pool="Pool_1",raid_level="raidDiskPool",state="complete"} 1
@@ -75,6 +84,45 @@ func TestStoragePoolsCollector(t *testing.T) {
 	}
 }
 
+func TestStoragePoolsCollectorDiskPoolThresholds(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+		data, _ := os.ReadFile("testdata/storage-pools-disk-pool.json")
+		rw.Write(data)
+	}))
+	defer server.Close()
+
+	baseURL, _ := url.Parse(server.URL)
+	target := config.Target{
+		Name:       "test-array",
+		BaseURL:    baseURL,
+		HttpClient: server.Client(),
+	}
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+	collector := NewStoragePoolsExporter(target, logger)
+
+	expected := `
+		# HELP eseries_pool_reconstruction_reserved_drives Number of drives worth of capacity the disk pool reserves for reconstruction
+		# TYPE eseries_pool_reconstruction_reserved_drives gauge
+		eseries_pool_reconstruction_reserved_drives{pool="Pool_1",raid_level="raidDiskPool"} 2
+		# HELP eseries_pool_reconstruction_reserved_drives_available Number of drive failures the disk pool reserved capacity can still absorb
+		# TYPE eseries_pool_reconstruction_reserved_drives_available gauge
+		eseries_pool_reconstruction_reserved_drives_available{pool="Pool_1",raid_level="raidDiskPool"} 1
+		# HELP eseries_pool_utilization_critical_threshold_ratio Utilization ratio (0-1) at which the array raises a disk pool critical alert
+		# TYPE eseries_pool_utilization_critical_threshold_ratio gauge
+		eseries_pool_utilization_critical_threshold_ratio{pool="Pool_1",raid_level="raidDiskPool"} 0.95
+		# HELP eseries_pool_utilization_warning_threshold_ratio Utilization ratio (0-1) at which the array raises a disk pool warning
+		# TYPE eseries_pool_utilization_warning_threshold_ratio gauge
+		eseries_pool_utilization_warning_threshold_ratio{pool="Pool_1",raid_level="raidDiskPool"} 0.85
+	`
+
+	if err := testutil.CollectAndCompare(collector, strings.NewReader(expected),
+		"eseries_pool_reconstruction_reserved_drives", "eseries_pool_reconstruction_reserved_drives_available",
+		"eseries_pool_utilization_critical_threshold_ratio", "eseries_pool_utilization_warning_threshold_ratio"); err != nil {
+		t.Errorf("Unexpected metrics:\n%v", err)
+	}
+}
+
 func TestStoragePoolsCollectorError(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
 		rw.WriteHeader(http.StatusNotFound)
@@ -25,6 +25,7 @@ var (
 
 type StorageSystem struct {
 	ID     string `json:"id"`
+	Name   string `json:"name"`
 	Status string `json:"status"`
 }
 
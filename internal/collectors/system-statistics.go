@@ -12,35 +12,81 @@ import (
 )
 
 type SystemStatistics struct {
-	AverageReadOpSize       float64 `json:"averageReadOpSize"`
-	AverageWriteOpSize      float64 `json:"averageWriteOpSize"`
-	CombinedHitResponseTime float64 `json:"combinedHitResponseTime"`
-	CombinedResponseTime    float64 `json:"combinedResponseTime"`
-	CpuAvgUtilization       float64 `json:"cpuAvgUtilization"`
-	MaxCpuUtilization       float64 `json:"maxCpuUtilization"`
-	ReadHitResponseTime     float64 `json:"readHitResponseTime"`
-	ReadPhysicalIOps        float64 `json:"readPhysicalIOps"`
-	ReadResponseTime        float64 `json:"readResponseTime"`
-	WriteHitResponseTime    float64 `json:"writeHitResponseTime"`
-	WritePhysicalIOps       float64 `json:"writePhysicalIOps"`
-	WriteResponseTime       float64 `json:"writeResponseTime"`
+	SourceController              string  `json:"sourceController"`
+	ObservedTimeInMS              string  `json:"observedTimeInMS"`
+	AverageReadOpSize             float64 `json:"averageReadOpSize"`
+	AverageWriteOpSize            float64 `json:"averageWriteOpSize"`
+	ReadIOps                      float64 `json:"readIOps"`
+	WriteIOps                     float64 `json:"writeIOps"`
+	OtherIOps                     float64 `json:"otherIOps"`
+	CombinedIOps                  float64 `json:"combinedIOps"`
+	ReadOps                       float64 `json:"readOps"`
+	WriteOps                      float64 `json:"writeOps"`
+	ReadPhysicalIOps              float64 `json:"readPhysicalIOps"`
+	WritePhysicalIOps             float64 `json:"writePhysicalIOps"`
+	ReadThroughput                float64 `json:"readThroughput"`
+	WriteThroughput               float64 `json:"writeThroughput"`
+	CombinedThroughput            float64 `json:"combinedThroughput"`
+	ReadResponseTime              float64 `json:"readResponseTime"`
+	ReadResponseTimeStdDev        float64 `json:"readResponseTimeStdDev"`
+	WriteResponseTime             float64 `json:"writeResponseTime"`
+	WriteResponseTimeStdDev       float64 `json:"writeResponseTimeStdDev"`
+	CombinedResponseTime          float64 `json:"combinedResponseTime"`
+	CombinedResponseTimeStdDev    float64 `json:"combinedResponseTimeStdDev"`
+	ReadHitResponseTime           float64 `json:"readHitResponseTime"`
+	ReadHitResponseTimeStdDev     float64 `json:"readHitResponseTimeStdDev"`
+	WriteHitResponseTime          float64 `json:"writeHitResponseTime"`
+	WriteHitResponseTimeStdDev    float64 `json:"writeHitResponseTimeStdDev"`
+	CombinedHitResponseTime       float64 `json:"combinedHitResponseTime"`
+	CombinedHitResponseTimeStdDev float64 `json:"combinedHitResponseTimeStdDev"`
+	CacheHitBytesPercent          float64 `json:"cacheHitBytesPercent"`
+	RandomIosPercent              float64 `json:"randomIosPercent"`
+	MirrorBytesPercent            float64 `json:"mirrorBytesPercent"`
+	FullStripeWritesBytesPercent  float64 `json:"fullStripeWritesBytesPercent"`
+	MaxCpuUtilization             float64 `json:"maxCpuUtilization"`
+	CpuAvgUtilization             float64 `json:"cpuAvgUtilization"`
+	Raid0BytesPercent             float64 `json:"raid0BytesPercent"`
+	Raid1BytesPercent             float64 `json:"raid1BytesPercent"`
+	Raid5BytesPercent             float64 `json:"raid5BytesPercent"`
+	Raid6BytesPercent             float64 `json:"raid6BytesPercent"`
+	DdpBytesPercent               float64 `json:"ddpBytesPercent"`
 }
 
 type SystemStatisticsCollector struct {
-	AverageReadOpSize       *prometheus.Desc
-	AverageWriteOpSize      *prometheus.Desc
-	CombinedHitResponseTime *prometheus.Desc
-	CombinedResponseTime    *prometheus.Desc
-	CpuAvgUtilization       *prometheus.Desc
-	MaxCpuUtilization       *prometheus.Desc
-	ReadHitResponseTime     *prometheus.Desc
-	ReadPhysicalIOps        *prometheus.Desc
-	ReadResponseTime        *prometheus.Desc
-	WriteHitResponseTime    *prometheus.Desc
-	WritePhysicalIOps       *prometheus.Desc
-	WriteResponseTime       *prometheus.Desc
-	target                  config.Target
-	logger                  *slog.Logger
+	AverageReadOpSize             *prometheus.Desc
+	AverageWriteOpSize            *prometheus.Desc
+	ReadIOps                      *prometheus.Desc
+	WriteIOps                     *prometheus.Desc
+	OtherIOps                     *prometheus.Desc
+	CombinedIOps                  *prometheus.Desc
+	ReadOps                       *prometheus.Desc
+	WriteOps                      *prometheus.Desc
+	ReadPhysicalIOps              *prometheus.Desc
+	WritePhysicalIOps             *prometheus.Desc
+	ReadThroughput                *prometheus.Desc
+	WriteThroughput               *prometheus.Desc
+	CombinedThroughput            *prometheus.Desc
+	ReadResponseTime              *prometheus.Desc
+	ReadResponseTimeStdDev        *prometheus.Desc
+	WriteResponseTime             *prometheus.Desc
+	WriteResponseTimeStdDev       *prometheus.Desc
+	CombinedResponseTime          *prometheus.Desc
+	CombinedResponseTimeStdDev    *prometheus.Desc
+	ReadHitResponseTime           *prometheus.Desc
+	ReadHitResponseTimeStdDev     *prometheus.Desc
+	WriteHitResponseTime          *prometheus.Desc
+	WriteHitResponseTimeStdDev    *prometheus.Desc
+	CombinedHitResponseTime       *prometheus.Desc
+	CombinedHitResponseTimeStdDev *prometheus.Desc
+	CacheHitBytesPercent          *prometheus.Desc
+	RandomIosPercent              *prometheus.Desc
+	MirrorBytesPercent            *prometheus.Desc
+	FullStripeWritesBytesPercent  *prometheus.Desc
+	MaxCpuUtilization             *prometheus.Desc
+	CpuAvgUtilization             *prometheus.Desc
+	RaidBytesPercent              *prometheus.Desc
+	target                        config.Target
+	logger                        *slog.Logger
 }
 
 func init() {
@@ -48,31 +94,72 @@ func init() {
 }
 
 func NewSystemStatisticsExporter(target config.Target, logger *slog.Logger) Collector {
+	labels := []string{"source_controller"}
 	return &SystemStatisticsCollector{
 		AverageReadOpSize: prometheus.NewDesc(prometheus.BuildFQName(namespace, "system", "average_read_op_size_bytes"),
-			"System statistic averageReadOpSize", nil, nil),
+			"System statistic averageReadOpSize", labels, nil),
 		AverageWriteOpSize: prometheus.NewDesc(prometheus.BuildFQName(namespace, "system", "average_write_op_size_bytes"),
-			"System statistic averageWriteOpSize", nil, nil),
-		CombinedHitResponseTime: prometheus.NewDesc(prometheus.BuildFQName(namespace, "system", "combined_hit_response_time_seconds"),
-			"System statistic CombinedHitResponseTime", nil, nil),
-		CombinedResponseTime: prometheus.NewDesc(prometheus.BuildFQName(namespace, "system", "combined_response_time_seconds"),
-			"System statistic combinedResponseTime", nil, nil),
-		CpuAvgUtilization: prometheus.NewDesc(prometheus.BuildFQName(namespace, "system", "cpu_average_utilization_ratio"),
-			"System statistic CpuAvgUtilization (0.0-1.0 ratio of CPU percent utilization)", nil, nil),
-		MaxCpuUtilization: prometheus.NewDesc(prometheus.BuildFQName(namespace, "system", "cpu_max_utilization_ratio"),
-			"System statistic MaxCpuUtilization (0.0-1.0 ratio of CPU percent utilization)", nil, nil),
-		ReadHitResponseTime: prometheus.NewDesc(prometheus.BuildFQName(namespace, "system", "read_hit_response_time_seconds"),
-			"System statistic ReadHitResponseTime", nil, nil),
+			"System statistic averageWriteOpSize", labels, nil),
+		ReadIOps: prometheus.NewDesc(prometheus.BuildFQName(namespace, "system", "read_iops"),
+			"System statistic readIOps", labels, nil),
+		WriteIOps: prometheus.NewDesc(prometheus.BuildFQName(namespace, "system", "write_iops"),
+			"System statistic writeIOps", labels, nil),
+		OtherIOps: prometheus.NewDesc(prometheus.BuildFQName(namespace, "system", "other_iops"),
+			"System statistic otherIOps", labels, nil),
+		CombinedIOps: prometheus.NewDesc(prometheus.BuildFQName(namespace, "system", "combined_iops"),
+			"System statistic combinedIOps", labels, nil),
+		ReadOps: prometheus.NewDesc(prometheus.BuildFQName(namespace, "system", "read_ops"),
+			"System statistic readOps", labels, nil),
+		WriteOps: prometheus.NewDesc(prometheus.BuildFQName(namespace, "system", "write_ops"),
+			"System statistic writeOps", labels, nil),
 		ReadPhysicalIOps: prometheus.NewDesc(prometheus.BuildFQName(namespace, "system", "read_physical_iops"),
-			"System statistic readPhysicalIOps", nil, nil),
-		ReadResponseTime: prometheus.NewDesc(prometheus.BuildFQName(namespace, "system", "read_response_time_seconds"),
-			"System statistic readResponseTime", nil, nil),
-		WriteHitResponseTime: prometheus.NewDesc(prometheus.BuildFQName(namespace, "system", "write_hit_response_time_seconds"),
-			"System statistic WriteHitResponseTime", nil, nil),
+			"System statistic readPhysicalIOps", labels, nil),
 		WritePhysicalIOps: prometheus.NewDesc(prometheus.BuildFQName(namespace, "system", "write_physical_iops"),
-			"System statistic writePhysicalIOps", nil, nil),
+			"System statistic writePhysicalIOps", labels, nil),
+		ReadThroughput: prometheus.NewDesc(prometheus.BuildFQName(namespace, "system", "read_throughput_bytes_per_second"),
+			"System statistic readThroughput", labels, nil),
+		WriteThroughput: prometheus.NewDesc(prometheus.BuildFQName(namespace, "system", "write_throughput_bytes_per_second"),
+			"System statistic writeThroughput", labels, nil),
+		CombinedThroughput: prometheus.NewDesc(prometheus.BuildFQName(namespace, "system", "combined_throughput_bytes_per_second"),
+			"System statistic combinedThroughput", labels, nil),
+		ReadResponseTime: prometheus.NewDesc(prometheus.BuildFQName(namespace, "system", "read_response_time_seconds"),
+			"System statistic readResponseTime", labels, nil),
+		ReadResponseTimeStdDev: prometheus.NewDesc(prometheus.BuildFQName(namespace, "system", "read_response_time_stddev_seconds"),
+			"System statistic readResponseTimeStdDev", labels, nil),
 		WriteResponseTime: prometheus.NewDesc(prometheus.BuildFQName(namespace, "system", "write_response_time_seconds"),
-			"System statistic writeResponseTime", nil, nil),
+			"System statistic writeResponseTime", labels, nil),
+		WriteResponseTimeStdDev: prometheus.NewDesc(prometheus.BuildFQName(namespace, "system", "write_response_time_stddev_seconds"),
+			"System statistic writeResponseTimeStdDev", labels, nil),
+		CombinedResponseTime: prometheus.NewDesc(prometheus.BuildFQName(namespace, "system", "combined_response_time_seconds"),
+			"System statistic combinedResponseTime", labels, nil),
+		CombinedResponseTimeStdDev: prometheus.NewDesc(prometheus.BuildFQName(namespace, "system", "combined_response_time_stddev_seconds"),
+			"System statistic combinedResponseTimeStdDev", labels, nil),
+		ReadHitResponseTime: prometheus.NewDesc(prometheus.BuildFQName(namespace, "system", "read_hit_response_time_seconds"),
+			"System statistic readHitResponseTime", labels, nil),
+		ReadHitResponseTimeStdDev: prometheus.NewDesc(prometheus.BuildFQName(namespace, "system", "read_hit_response_time_stddev_seconds"),
+			"System statistic readHitResponseTimeStdDev", labels, nil),
+		WriteHitResponseTime: prometheus.NewDesc(prometheus.BuildFQName(namespace, "system", "write_hit_response_time_seconds"),
+			"System statistic writeHitResponseTime", labels, nil),
+		WriteHitResponseTimeStdDev: prometheus.NewDesc(prometheus.BuildFQName(namespace, "system", "write_hit_response_time_stddev_seconds"),
+			"System statistic writeHitResponseTimeStdDev", labels, nil),
+		CombinedHitResponseTime: prometheus.NewDesc(prometheus.BuildFQName(namespace, "system", "combined_hit_response_time_seconds"),
+			"System statistic combinedHitResponseTime", labels, nil),
+		CombinedHitResponseTimeStdDev: prometheus.NewDesc(prometheus.BuildFQName(namespace, "system", "combined_hit_response_time_stddev_seconds"),
+			"System statistic combinedHitResponseTimeStdDev", labels, nil),
+		CacheHitBytesPercent: prometheus.NewDesc(prometheus.BuildFQName(namespace, "system", "cache_hit_bytes_ratio"),
+			"System statistic cacheHitBytesPercent (0.0-1.0 ratio of bytes served from cache)", labels, nil),
+		RandomIosPercent: prometheus.NewDesc(prometheus.BuildFQName(namespace, "system", "random_ios_ratio"),
+			"System statistic randomIosPercent (0.0-1.0 ratio of random I/O)", labels, nil),
+		MirrorBytesPercent: prometheus.NewDesc(prometheus.BuildFQName(namespace, "system", "mirror_bytes_ratio"),
+			"System statistic mirrorBytesPercent (0.0-1.0 ratio of bytes mirrored to the partner controller)", labels, nil),
+		FullStripeWritesBytesPercent: prometheus.NewDesc(prometheus.BuildFQName(namespace, "system", "full_stripe_writes_bytes_ratio"),
+			"System statistic fullStripeWritesBytesPercent (0.0-1.0 ratio of bytes written as full stripes)", labels, nil),
+		MaxCpuUtilization: prometheus.NewDesc(prometheus.BuildFQName(namespace, "system", "cpu_max_utilization_ratio"),
+			"System statistic maxCpuUtilization (0.0-1.0 ratio of CPU percent utilization)", labels, nil),
+		CpuAvgUtilization: prometheus.NewDesc(prometheus.BuildFQName(namespace, "system", "cpu_average_utilization_ratio"),
+			"System statistic cpuAvgUtilization (0.0-1.0 ratio of CPU percent utilization)", labels, nil),
+		RaidBytesPercent: prometheus.NewDesc(prometheus.BuildFQName(namespace, "system", "raid_bytes_ratio"),
+			"System statistic raid0/1/5/6 and ddp BytesPercent (0.0-1.0 ratio of bytes transferred per RAID level)", append(labels, "raid_level"), nil),
 		target: target,
 		logger: logger,
 	}
@@ -81,16 +168,36 @@ func NewSystemStatisticsExporter(target config.Target, logger *slog.Logger) Coll
 func (c *SystemStatisticsCollector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- c.AverageReadOpSize
 	ch <- c.AverageWriteOpSize
-	ch <- c.CombinedHitResponseTime
-	ch <- c.CombinedResponseTime
-	ch <- c.CpuAvgUtilization
-	ch <- c.MaxCpuUtilization
-	ch <- c.ReadHitResponseTime
+	ch <- c.ReadIOps
+	ch <- c.WriteIOps
+	ch <- c.OtherIOps
+	ch <- c.CombinedIOps
+	ch <- c.ReadOps
+	ch <- c.WriteOps
 	ch <- c.ReadPhysicalIOps
-	ch <- c.ReadResponseTime
-	ch <- c.WriteHitResponseTime
 	ch <- c.WritePhysicalIOps
+	ch <- c.ReadThroughput
+	ch <- c.WriteThroughput
+	ch <- c.CombinedThroughput
+	ch <- c.ReadResponseTime
+	ch <- c.ReadResponseTimeStdDev
 	ch <- c.WriteResponseTime
+	ch <- c.WriteResponseTimeStdDev
+	ch <- c.CombinedResponseTime
+	ch <- c.CombinedResponseTimeStdDev
+	ch <- c.ReadHitResponseTime
+	ch <- c.ReadHitResponseTimeStdDev
+	ch <- c.WriteHitResponseTime
+	ch <- c.WriteHitResponseTimeStdDev
+	ch <- c.CombinedHitResponseTime
+	ch <- c.CombinedHitResponseTimeStdDev
+	ch <- c.CacheHitBytesPercent
+	ch <- c.RandomIosPercent
+	ch <- c.MirrorBytesPercent
+	ch <- c.FullStripeWritesBytesPercent
+	ch <- c.MaxCpuUtilization
+	ch <- c.CpuAvgUtilization
+	ch <- c.RaidBytesPercent
 }
 
 func (c *SystemStatisticsCollector) Collect(ch chan<- prometheus.Metric) {
@@ -103,20 +210,51 @@ func (c *SystemStatisticsCollector) Collect(ch chan<- prometheus.Metric) {
 		errorMetric = 1
 	}
 
+	observations := newStatisticsObservations(c.target)
 	if err == nil {
-		ch <- prometheus.MustNewConstMetric(c.AverageReadOpSize, prometheus.GaugeValue, statistics.AverageReadOpSize)
-		ch <- prometheus.MustNewConstMetric(c.AverageWriteOpSize, prometheus.GaugeValue, statistics.AverageWriteOpSize)
-		ch <- prometheus.MustNewConstMetric(c.CombinedHitResponseTime, prometheus.GaugeValue, statistics.CombinedHitResponseTime)
-		ch <- prometheus.MustNewConstMetric(c.CombinedResponseTime, prometheus.GaugeValue, statistics.CombinedResponseTime)
-		ch <- prometheus.MustNewConstMetric(c.CpuAvgUtilization, prometheus.GaugeValue, statistics.CpuAvgUtilization)
-		ch <- prometheus.MustNewConstMetric(c.MaxCpuUtilization, prometheus.GaugeValue, statistics.MaxCpuUtilization)
-		ch <- prometheus.MustNewConstMetric(c.ReadHitResponseTime, prometheus.GaugeValue, statistics.ReadHitResponseTime)
-		ch <- prometheus.MustNewConstMetric(c.ReadPhysicalIOps, prometheus.GaugeValue, statistics.ReadPhysicalIOps)
-		ch <- prometheus.MustNewConstMetric(c.ReadResponseTime, prometheus.GaugeValue, statistics.ReadResponseTime)
-		ch <- prometheus.MustNewConstMetric(c.WriteHitResponseTime, prometheus.GaugeValue, statistics.WriteHitResponseTime)
-		ch <- prometheus.MustNewConstMetric(c.WritePhysicalIOps, prometheus.GaugeValue, statistics.WritePhysicalIOps)
-		ch <- prometheus.MustNewConstMetric(c.WriteResponseTime, prometheus.GaugeValue, statistics.WriteResponseTime)
+		source := statistics.SourceController
+		if observed, fresh := observations.observe(statistics.ObservedTimeInMS); !fresh {
+			c.logger.Debug("Skipping stale system statistics", "observed", observed)
+		} else {
+			ch <- observations.metric(observed, prometheus.MustNewConstMetric(c.AverageReadOpSize, prometheus.GaugeValue, statistics.AverageReadOpSize, source))
+			ch <- observations.metric(observed, prometheus.MustNewConstMetric(c.AverageWriteOpSize, prometheus.GaugeValue, statistics.AverageWriteOpSize, source))
+			ch <- observations.metric(observed, prometheus.MustNewConstMetric(c.ReadIOps, prometheus.GaugeValue, statistics.ReadIOps, source))
+			ch <- observations.metric(observed, prometheus.MustNewConstMetric(c.WriteIOps, prometheus.GaugeValue, statistics.WriteIOps, source))
+			ch <- observations.metric(observed, prometheus.MustNewConstMetric(c.OtherIOps, prometheus.GaugeValue, statistics.OtherIOps, source))
+			ch <- observations.metric(observed, prometheus.MustNewConstMetric(c.CombinedIOps, prometheus.GaugeValue, statistics.CombinedIOps, source))
+			ch <- observations.metric(observed, prometheus.MustNewConstMetric(c.ReadOps, prometheus.GaugeValue, statistics.ReadOps, source))
+			ch <- observations.metric(observed, prometheus.MustNewConstMetric(c.WriteOps, prometheus.GaugeValue, statistics.WriteOps, source))
+			ch <- observations.metric(observed, prometheus.MustNewConstMetric(c.ReadPhysicalIOps, prometheus.GaugeValue, statistics.ReadPhysicalIOps, source))
+			ch <- observations.metric(observed, prometheus.MustNewConstMetric(c.WritePhysicalIOps, prometheus.GaugeValue, statistics.WritePhysicalIOps, source))
+			ch <- observations.metric(observed, prometheus.MustNewConstMetric(c.ReadThroughput, prometheus.GaugeValue, statistics.ReadThroughput, source))
+			ch <- observations.metric(observed, prometheus.MustNewConstMetric(c.WriteThroughput, prometheus.GaugeValue, statistics.WriteThroughput, source))
+			ch <- observations.metric(observed, prometheus.MustNewConstMetric(c.CombinedThroughput, prometheus.GaugeValue, statistics.CombinedThroughput, source))
+			ch <- observations.metric(observed, prometheus.MustNewConstMetric(c.ReadResponseTime, prometheus.GaugeValue, statistics.ReadResponseTime, source))
+			ch <- observations.metric(observed, prometheus.MustNewConstMetric(c.ReadResponseTimeStdDev, prometheus.GaugeValue, statistics.ReadResponseTimeStdDev, source))
+			ch <- observations.metric(observed, prometheus.MustNewConstMetric(c.WriteResponseTime, prometheus.GaugeValue, statistics.WriteResponseTime, source))
+			ch <- observations.metric(observed, prometheus.MustNewConstMetric(c.WriteResponseTimeStdDev, prometheus.GaugeValue, statistics.WriteResponseTimeStdDev, source))
+			ch <- observations.metric(observed, prometheus.MustNewConstMetric(c.CombinedResponseTime, prometheus.GaugeValue, statistics.CombinedResponseTime, source))
+			ch <- observations.metric(observed, prometheus.MustNewConstMetric(c.CombinedResponseTimeStdDev, prometheus.GaugeValue, statistics.CombinedResponseTimeStdDev, source))
+			ch <- observations.metric(observed, prometheus.MustNewConstMetric(c.ReadHitResponseTime, prometheus.GaugeValue, statistics.ReadHitResponseTime, source))
+			ch <- observations.metric(observed, prometheus.MustNewConstMetric(c.ReadHitResponseTimeStdDev, prometheus.GaugeValue, statistics.ReadHitResponseTimeStdDev, source))
+			ch <- observations.metric(observed, prometheus.MustNewConstMetric(c.WriteHitResponseTime, prometheus.GaugeValue, statistics.WriteHitResponseTime, source))
+			ch <- observations.metric(observed, prometheus.MustNewConstMetric(c.WriteHitResponseTimeStdDev, prometheus.GaugeValue, statistics.WriteHitResponseTimeStdDev, source))
+			ch <- observations.metric(observed, prometheus.MustNewConstMetric(c.CombinedHitResponseTime, prometheus.GaugeValue, statistics.CombinedHitResponseTime, source))
+			ch <- observations.metric(observed, prometheus.MustNewConstMetric(c.CombinedHitResponseTimeStdDev, prometheus.GaugeValue, statistics.CombinedHitResponseTimeStdDev, source))
+			ch <- observations.metric(observed, prometheus.MustNewConstMetric(c.CacheHitBytesPercent, prometheus.GaugeValue, statistics.CacheHitBytesPercent, source))
+			ch <- observations.metric(observed, prometheus.MustNewConstMetric(c.RandomIosPercent, prometheus.GaugeValue, statistics.RandomIosPercent, source))
+			ch <- observations.metric(observed, prometheus.MustNewConstMetric(c.MirrorBytesPercent, prometheus.GaugeValue, statistics.MirrorBytesPercent, source))
+			ch <- observations.metric(observed, prometheus.MustNewConstMetric(c.FullStripeWritesBytesPercent, prometheus.GaugeValue, statistics.FullStripeWritesBytesPercent, source))
+			ch <- observations.metric(observed, prometheus.MustNewConstMetric(c.MaxCpuUtilization, prometheus.GaugeValue, statistics.MaxCpuUtilization, source))
+			ch <- observations.metric(observed, prometheus.MustNewConstMetric(c.CpuAvgUtilization, prometheus.GaugeValue, statistics.CpuAvgUtilization, source))
+			ch <- observations.metric(observed, prometheus.MustNewConstMetric(c.RaidBytesPercent, prometheus.GaugeValue, statistics.Raid0BytesPercent, source, "raid0"))
+			ch <- observations.metric(observed, prometheus.MustNewConstMetric(c.RaidBytesPercent, prometheus.GaugeValue, statistics.Raid1BytesPercent, source, "raid1"))
+			ch <- observations.metric(observed, prometheus.MustNewConstMetric(c.RaidBytesPercent, prometheus.GaugeValue, statistics.Raid5BytesPercent, source, "raid5"))
+			ch <- observations.metric(observed, prometheus.MustNewConstMetric(c.RaidBytesPercent, prometheus.GaugeValue, statistics.Raid6BytesPercent, source, "raid6"))
+			ch <- observations.metric(observed, prometheus.MustNewConstMetric(c.RaidBytesPercent, prometheus.GaugeValue, statistics.DdpBytesPercent, source, "raidDiskPool"))
+		}
 	}
+	observations.collect(ch, "system-statistics")
 
 	ch <- prometheus.MustNewConstMetric(collectError, prometheus.GaugeValue, float64(errorMetric), "system-statistics")
 	ch <- prometheus.MustNewConstMetric(collectDuration, prometheus.GaugeValue, time.Since(collectTime).Seconds(), "system-statistics")
@@ -133,14 +271,33 @@ func (c *SystemStatisticsCollector) collect() (SystemStatistics, error) {
 		return statistics, err
 	}
 	// Convert milliseconds to seconds
-	statistics.CombinedHitResponseTime = statistics.CombinedHitResponseTime * 0.001
+	statistics.ReadResponseTime = statistics.ReadResponseTime * 0.001
+	statistics.ReadResponseTimeStdDev = statistics.ReadResponseTimeStdDev * 0.001
+	statistics.WriteResponseTime = statistics.WriteResponseTime * 0.001
+	statistics.WriteResponseTimeStdDev = statistics.WriteResponseTimeStdDev * 0.001
 	statistics.CombinedResponseTime = statistics.CombinedResponseTime * 0.001
+	statistics.CombinedResponseTimeStdDev = statistics.CombinedResponseTimeStdDev * 0.001
 	statistics.ReadHitResponseTime = statistics.ReadHitResponseTime * 0.001
-	statistics.ReadResponseTime = statistics.ReadResponseTime * 0.001
+	statistics.ReadHitResponseTimeStdDev = statistics.ReadHitResponseTimeStdDev * 0.001
 	statistics.WriteHitResponseTime = statistics.WriteHitResponseTime * 0.001
-	statistics.WriteResponseTime = statistics.WriteResponseTime * 0.001
+	statistics.WriteHitResponseTimeStdDev = statistics.WriteHitResponseTimeStdDev * 0.001
+	statistics.CombinedHitResponseTime = statistics.CombinedHitResponseTime * 0.001
+	statistics.CombinedHitResponseTimeStdDev = statistics.CombinedHitResponseTimeStdDev * 0.001
+	// Convert MiB/s to bytes/s
+	statistics.ReadThroughput = statistics.ReadThroughput * 1024 * 1024
+	statistics.WriteThroughput = statistics.WriteThroughput * 1024 * 1024
+	statistics.CombinedThroughput = statistics.CombinedThroughput * 1024 * 1024
 	// Convert from percent to ratio
+	statistics.CacheHitBytesPercent = statistics.CacheHitBytesPercent / 100
+	statistics.RandomIosPercent = statistics.RandomIosPercent / 100
+	statistics.MirrorBytesPercent = statistics.MirrorBytesPercent / 100
+	statistics.FullStripeWritesBytesPercent = statistics.FullStripeWritesBytesPercent / 100
 	statistics.MaxCpuUtilization = statistics.MaxCpuUtilization / 100
 	statistics.CpuAvgUtilization = statistics.CpuAvgUtilization / 100
+	statistics.Raid0BytesPercent = statistics.Raid0BytesPercent / 100
+	statistics.Raid1BytesPercent = statistics.Raid1BytesPercent / 100
+	statistics.Raid5BytesPercent = statistics.Raid5BytesPercent / 100
+	statistics.Raid6BytesPercent = statistics.Raid6BytesPercent / 100
+	statistics.DdpBytesPercent = statistics.DdpBytesPercent / 100
 	return statistics, nil
 }
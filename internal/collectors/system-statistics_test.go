@@ -24,7 +24,26 @@ func TestSystemStatisticsCollector(t *testing.T) {
 eseries_exporter_collect_error{collector="system-statistics"} 0
 # HELP eseries_system_average_read_op_size_bytes System statistic averageReadOpSize
 # TYPE eseries_system_average_read_op_size_bytes gauge
-eseries_system_average_read_op_size_bytes 17357.11013434037
+eseries_system_average_read_op_size_bytes{source_controller=""} 17357.11013434037
+# HELP eseries_system_cache_hit_bytes_ratio System statistic cacheHitBytesPercent (0.0-1.0 ratio of bytes served from cache)
+# TYPE eseries_system_cache_hit_bytes_ratio gauge
+eseries_system_cache_hit_bytes_ratio{source_controller=""} 0.0036320427340620597
+# HELP eseries_system_combined_iops System statistic combinedIOps
+# TYPE eseries_system_combined_iops gauge
+eseries_system_combined_iops{source_controller=""} 2883
+# HELP eseries_system_combined_throughput_bytes_per_second System statistic combinedThroughput
+# TYPE eseries_system_combined_throughput_bytes_per_second gauge
+eseries_system_combined_throughput_bytes_per_second{source_controller=""} 1.53173675e+08
+# HELP eseries_system_raid_bytes_ratio System statistic raid0/1/5/6 and ddp BytesPercent (0.0-1.0 ratio of bytes transferred per RAID level)
+# TYPE eseries_system_raid_bytes_ratio gauge
+eseries_system_raid_bytes_ratio{raid_level="raid0",source_controller=""} 0
+eseries_system_raid_bytes_ratio{raid_level="raid1",source_controller=""} 0
+eseries_system_raid_bytes_ratio{raid_level="raid5",source_controller=""} 0
+eseries_system_raid_bytes_ratio{raid_level="raid6",source_controller=""} 0
+eseries_system_raid_bytes_ratio{raid_level="raidDiskPool",source_controller=""} 0.8474665005806863
+# HELP eseries_system_read_response_time_stddev_seconds System statistic readResponseTimeStdDev
+# TYPE eseries_system_read_response_time_stddev_seconds gauge
+eseries_system_read_response_time_stddev_seconds{source_controller=""} 1.0702514307518474
 `
 	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
 		_, _ = rw.Write(fixtureData)
@@ -43,11 +62,13 @@ eseries_system_average_read_op_size_bytes 17357.11013434037
 	gatherers := setupGatherer(collector)
 	if val, err := testutil.GatherAndCount(gatherers); err != nil {
 		t.Errorf("Unexpected error: %v", err)
-	} else if val != 14 {
-		t.Errorf("Unexpected collection count %d, expected 14", val)
+	} else if val != 39 {
+		t.Errorf("Unexpected collection count %d, expected 39", val)
 	}
 	if err := testutil.GatherAndCompare(gatherers, strings.NewReader(expected),
-		"eseries_system_average_read_op_size_bytes",
+		"eseries_system_average_read_op_size_bytes", "eseries_system_cache_hit_bytes_ratio",
+		"eseries_system_combined_iops", "eseries_system_combined_throughput_bytes_per_second",
+		"eseries_system_raid_bytes_ratio", "eseries_system_read_response_time_stddev_seconds",
 		"eseries_exporter_collect_error"); err != nil {
 		t.Errorf("unexpected collecting result:\n%s", err)
 	}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log/slog"
 	"strconv"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 
@@ -26,12 +27,36 @@ type Volume struct {
 	Mapped           bool            `json:"mapped"`
 	RaidLevel        string          `json:"raidLevel"`
 	VolumeUse        string          `json:"volumeUse"`
+	Metadata         []VolumeTag     `json:"metadata"`
+}
+
+// VolumeTag is a key/value pair attached to a volume, workloads are linked by the workloadId key.
+type VolumeTag struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+type Workload struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
 }
 
 type VolumeMapping struct {
 	ID string `json:"id"`
 }
 
+type ThinVolume struct {
+	ID                         string      `json:"id"`
+	Label                      string      `json:"label"`
+	Capacity                   string      `json:"capacity"`
+	CurrentProvisionedCapacity string      `json:"currentProvisionedCapacity"`
+	ProvisionedCapacityQuota   string      `json:"provisionedCapacityQuota"`
+	GrowthAlertThreshold       int         `json:"growthAlertThreshold"`
+	VolumeGroupRef             string      `json:"volumeGroupRef"`
+	Status                     string      `json:"status"`
+	Metadata                   []VolumeTag `json:"metadata"`
+}
+
 type VolumesCollector struct {
 	target config.Target
 	logger *slog.Logger
@@ -42,6 +67,11 @@ type VolumesCollector struct {
 	mappingsTotal   *prometheus.Desc
 	thinProvisioned *prometheus.Desc
 	offline         *prometheus.Desc
+
+	thinProvisionedBytes     *prometheus.Desc
+	thinRepositoryBytes      *prometheus.Desc
+	thinQuotaBytes           *prometheus.Desc
+	thinGrowthAlertThreshold *prometheus.Desc
 }
 
 func init() {
@@ -58,32 +88,52 @@ func NewVolumesExporter(target config.Target, logger *slog.Logger) Collector {
 		capacityBytes: prometheus.NewDesc(
 			prometheus.BuildFQName(namespace, "volume", "capacity_bytes"),
 			"Total capacity of the volume in bytes",
-			[]string{"volume", "pool", "status", "raid_level", "type"}, nil,
+			[]string{"volume", "pool", "status", "raid_level", "type", "workload"}, nil,
 		),
 		status: prometheus.NewDesc(
 			prometheus.BuildFQName(namespace, "volume", "status"),
 			"Status of the volume (1 for optimal, 0 otherwise)",
-			[]string{"volume", "pool", "status"}, nil,
+			[]string{"volume", "pool", "status", "workload"}, nil,
 		),
 		mapped: prometheus.NewDesc(
 			prometheus.BuildFQName(namespace, "volume", "mapped"),
 			"Whether the volume is mapped to a host (1) or not (0)",
-			[]string{"volume", "pool"}, nil,
+			[]string{"volume", "pool", "workload"}, nil,
 		),
 		mappingsTotal: prometheus.NewDesc(
 			prometheus.BuildFQName(namespace, "volume", "mappings_total"),
 			"Number of host mappings for this volume",
-			[]string{"volume", "pool"}, nil,
+			[]string{"volume", "pool", "workload"}, nil,
 		),
 		thinProvisioned: prometheus.NewDesc(
 			prometheus.BuildFQName(namespace, "volume", "thin_provisioned"),
 			"Whether the volume uses thin provisioning (1) or not (0)",
-			[]string{"volume", "pool"}, nil,
+			[]string{"volume", "pool", "workload"}, nil,
 		),
 		offline: prometheus.NewDesc(
 			prometheus.BuildFQName(namespace, "volume", "offline"),
 			"Whether the volume is offline (1) or online (0)",
-			[]string{"volume", "pool"}, nil,
+			[]string{"volume", "pool", "workload"}, nil,
+		),
+		thinProvisionedBytes: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "thin_volume", "provisioned_bytes"),
+			"Virtual capacity presented to hosts by the thin volume in bytes",
+			[]string{"volume", "pool", "workload"}, nil,
+		),
+		thinRepositoryBytes: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "thin_volume", "repository_bytes"),
+			"Pool capacity currently consumed by the thin volume repository in bytes",
+			[]string{"volume", "pool", "workload"}, nil,
+		),
+		thinQuotaBytes: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "thin_volume", "quota_bytes"),
+			"Maximum capacity the thin volume repository may grow to in bytes",
+			[]string{"volume", "pool", "workload"}, nil,
+		),
+		thinGrowthAlertThreshold: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "thin_volume", "growth_alert_threshold_ratio"),
+			"Repository utilization ratio (0-1) at which the array raises a growth alert",
+			[]string{"volume", "pool", "workload"}, nil,
 		),
 	}
 }
@@ -95,17 +145,34 @@ func (c *VolumesCollector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- c.mappingsTotal
 	ch <- c.thinProvisioned
 	ch <- c.offline
+	ch <- c.thinProvisionedBytes
+	ch <- c.thinRepositoryBytes
+	ch <- c.thinQuotaBytes
+	ch <- c.thinGrowthAlertThreshold
 }
 
 func (c *VolumesCollector) Collect(ch chan<- prometheus.Metric) {
+	collectTime := time.Now()
+	var errorMetric int
 	volumes, err := c.collectVolumes()
 	if err != nil {
 		c.logger.Error("Collection failed", "error", err)
-		return
+		errorMetric = 1
+	}
+
+	// Volumes are still exported, with an empty workload label, when workloads fail
+	var names map[string]string
+	workloads, err := c.collectWorkloads()
+	if err != nil {
+		c.logger.Error("Workload collection failed", "error", err)
+		errorMetric = 1
+	} else {
+		names = workloadNames(workloads)
 	}
 
 	for _, volume := range volumes {
 		poolLabel := c.getPoolLabel(volume.VolumeGroupRef)
+		workload := volumeWorkload(volume.Metadata, names)
 
 		// Capacity
 		capacity, _ := strconv.ParseFloat(volume.TotalSizeInBytes, 64)
@@ -113,7 +180,7 @@ func (c *VolumesCollector) Collect(ch chan<- prometheus.Metric) {
 			c.capacityBytes,
 			prometheus.GaugeValue,
 			capacity,
-			volume.Label, poolLabel, volume.Status, volume.RaidLevel, volume.VolumeUse,
+			volume.Label, poolLabel, volume.Status, volume.RaidLevel, volume.VolumeUse, workload,
 		)
 
 		// Status (1 for optimal, 0 otherwise)
@@ -125,7 +192,7 @@ func (c *VolumesCollector) Collect(ch chan<- prometheus.Metric) {
 			c.status,
 			prometheus.GaugeValue,
 			statusValue,
-			volume.Label, poolLabel, volume.Status,
+			volume.Label, poolLabel, volume.Status, workload,
 		)
 
 		// Mapped
@@ -137,7 +204,7 @@ func (c *VolumesCollector) Collect(ch chan<- prometheus.Metric) {
 			c.mapped,
 			prometheus.GaugeValue,
 			mappedValue,
-			volume.Label, poolLabel,
+			volume.Label, poolLabel, workload,
 		)
 
 		// Mappings total
@@ -145,7 +212,7 @@ func (c *VolumesCollector) Collect(ch chan<- prometheus.Metric) {
 			c.mappingsTotal,
 			prometheus.GaugeValue,
 			float64(len(volume.ListOfMappings)),
-			volume.Label, poolLabel,
+			volume.Label, poolLabel, workload,
 		)
 
 		// Thin provisioned
@@ -157,7 +224,7 @@ func (c *VolumesCollector) Collect(ch chan<- prometheus.Metric) {
 			c.thinProvisioned,
 			prometheus.GaugeValue,
 			thinValue,
-			volume.Label, poolLabel,
+			volume.Label, poolLabel, workload,
 		)
 
 		// Offline
@@ -169,9 +236,55 @@ func (c *VolumesCollector) Collect(ch chan<- prometheus.Metric) {
 			c.offline,
 			prometheus.GaugeValue,
 			offlineValue,
-			volume.Label, poolLabel,
+			volume.Label, poolLabel, workload,
+		)
+	}
+
+	thinVolumes, err := c.collectThinVolumes()
+	if err != nil {
+		c.logger.Error("Collection failed", "error", err)
+		errorMetric = 1
+	}
+
+	for _, volume := range thinVolumes {
+		poolLabel := c.getPoolLabel(volume.VolumeGroupRef)
+		workload := volumeWorkload(volume.Metadata, names)
+
+		provisioned, _ := strconv.ParseFloat(volume.Capacity, 64)
+		ch <- prometheus.MustNewConstMetric(
+			c.thinProvisionedBytes,
+			prometheus.GaugeValue,
+			provisioned,
+			volume.Label, poolLabel, workload,
+		)
+
+		repository, _ := strconv.ParseFloat(volume.CurrentProvisionedCapacity, 64)
+		ch <- prometheus.MustNewConstMetric(
+			c.thinRepositoryBytes,
+			prometheus.GaugeValue,
+			repository,
+			volume.Label, poolLabel, workload,
+		)
+
+		quota, _ := strconv.ParseFloat(volume.ProvisionedCapacityQuota, 64)
+		ch <- prometheus.MustNewConstMetric(
+			c.thinQuotaBytes,
+			prometheus.GaugeValue,
+			quota,
+			volume.Label, poolLabel, workload,
+		)
+
+		// Threshold is reported as a percentage
+		ch <- prometheus.MustNewConstMetric(
+			c.thinGrowthAlertThreshold,
+			prometheus.GaugeValue,
+			float64(volume.GrowthAlertThreshold)/100,
+			volume.Label, poolLabel, workload,
 		)
 	}
+
+	ch <- prometheus.MustNewConstMetric(collectError, prometheus.GaugeValue, float64(errorMetric), "volumes")
+	ch <- prometheus.MustNewConstMetric(collectDuration, prometheus.GaugeValue, time.Since(collectTime).Seconds(), "volumes")
 }
 
 func (c *VolumesCollector) collectVolumes() ([]Volume, error) {
@@ -188,9 +301,93 @@ func (c *VolumesCollector) collectVolumes() ([]Volume, error) {
 	return volumes, nil
 }
 
+func (c *VolumesCollector) collectThinVolumes() ([]ThinVolume, error) {
+	thinVolumesBody, err := getRequest(c.target, fmt.Sprintf("/devmgr/v2/storage-systems/%s/thin-volumes", c.target.Name), c.logger)
+	if err != nil {
+		return nil, err
+	}
+
+	var thinVolumes []ThinVolume
+	if err := json.Unmarshal(thinVolumesBody, &thinVolumes); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal thin volumes: %w", err)
+	}
+
+	return thinVolumes, nil
+}
+
+// collectWorkloads returns no workloads on firmware without workload support.
+func (c *VolumesCollector) collectWorkloads() ([]Workload, error) {
+	workloadsBody, err := getOptionalRequest(c.target, fmt.Sprintf("/devmgr/v2/storage-systems/%s/workloads", c.target.Name), c.logger)
+	if isNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var workloads []Workload
+	if err := json.Unmarshal(workloadsBody, &workloads); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal workloads: %w", err)
+	}
+
+	return workloads, nil
+}
+
 func (c *VolumesCollector) getPoolLabel(poolRef string) string {
 	if poolRef == "" {
 		return "unknown"
 	}
 	return poolRef
 }
+
+// volumeLabels maps volume references to their user assigned labels.
+func volumeLabels(volumes []Volume) map[string]string {
+	labels := make(map[string]string)
+	for _, v := range volumes {
+		labels[v.ID] = v.Label
+	}
+	return labels
+}
+
+// addThinVolumeLabels adds the labels of thin volumes, which the volumes
+// endpoint does not list, to labels built by volumeLabels.
+func addThinVolumeLabels(labels map[string]string, thinVolumes []ThinVolume) map[string]string {
+	for _, v := range thinVolumes {
+		labels[v.ID] = v.Label
+	}
+	return labels
+}
+
+// lookupLabel returns the label for ref, falling back to the reference itself
+// when it is not known.
+func lookupLabel(labels map[string]string, ref string) string {
+	if label, ok := labels[ref]; ok && label != "" {
+		return label
+	}
+	if ref == "" {
+		return "unknown"
+	}
+	return ref
+}
+
+// workloadNames maps workload IDs to their names.
+func workloadNames(workloads []Workload) map[string]string {
+	names := make(map[string]string)
+	for _, w := range workloads {
+		names[w.ID] = w.Name
+	}
+	return names
+}
+
+// volumeWorkload returns the name of the workload a volume is tagged with, or
+// an empty string for untagged volumes and when workloads could not be fetched.
+func volumeWorkload(metadata []VolumeTag, names map[string]string) string {
+	if names == nil {
+		return ""
+	}
+	for _, m := range metadata {
+		if m.Key == "workloadId" {
+			return lookupLabel(names, m.Value)
+		}
+	}
+	return ""
+}
@@ -15,7 +15,17 @@ import (
 )
 
 func TestVolumesCollector(t *testing.T) {
-	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if strings.HasSuffix(req.URL.Path, "thin-volumes") {
+			data, _ := os.ReadFile("testdata/thin-volumes-response.json")
+			rw.Write(data)
+			return
+		}
+		if strings.HasSuffix(req.URL.Path, "workloads") {
+			data, _ := os.ReadFile("testdata/workloads.json")
+			rw.Write(data)
+			return
+		}
 		data, _ := os.ReadFile("testdata/volumes-response.json")
 		rw.Write(data)
 	}))
@@ -32,9 +42,11 @@ func TestVolumesCollector(t *testing.T) {
 	collector := NewVolumesExporter(target, logger)
 
 	// Test metrics collection
-	expectedMetrics := 18 // 3 volumes * 6 metrics each
-	count := testutil.CollectAndCount(collector)
-	if count != expectedMetrics {
+	expectedMetrics := 24 // 3 volumes * 6 metrics each + 1 thin volume * 4 metrics + error and duration
+	gatherers := setupGatherer(collector)
+	if count, err := testutil.GatherAndCount(gatherers); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	} else if count != expectedMetrics {
 		t.Errorf("Expected %d metrics, got %d", expectedMetrics, count)
 	}
 
@@ -42,37 +54,108 @@ func TestVolumesCollector(t *testing.T) {
 	expected := `
 		# HELP eseries_volume_capacity_bytes Total capacity of the volume in bytes
 		# TYPE eseries_volume_capacity_bytes gauge
-		eseries_volume_capacity_bytes{pool="040000006D039EA000CF32BB000000D868E4C6E2",raid_level="raid6",status="optimal",type="standardVolume",volume="Volume_1"} 5.1316269252608e+13
-		eseries_volume_capacity_bytes{pool="040000006D039EA000CF32BB000000D868E4C6E2",raid_level="raid6",status="optimal",type="thinVolume",volume="Volume_2"} 5.1316269252608e+13
-		eseries_volume_capacity_bytes{pool="040000006D039EA000CF32BB000000D868E4C6E2",raid_level="raid6",status="failed",type="standardVolume",volume="Volume_3"} 1.073741824e+10
+		eseries_volume_capacity_bytes{pool="040000006D039EA000CF32BB000000D868E4C6E2",raid_level="raid6",status="optimal",type="standardVolume",volume="Volume_1",workload="Oracle"} 5.1316269252608e+13
+		eseries_volume_capacity_bytes{pool="040000006D039EA000CF32BB000000D868E4C6E2",raid_level="raid6",status="optimal",type="thinVolume",volume="Volume_2",workload="Oracle"} 5.1316269252608e+13
+		eseries_volume_capacity_bytes{pool="040000006D039EA000CF32BB000000D868E4C6E2",raid_level="raid6",status="failed",type="standardVolume",volume="Volume_3",workload=""} 1.073741824e+10
 		# HELP eseries_volume_mapped Whether the volume is mapped to a host (1) or not (0)
 		# TYPE eseries_volume_mapped gauge
-		eseries_volume_mapped{pool="040000006D039EA000CF32BB000000D868E4C6E2",volume="Volume_1"} 1
-		eseries_volume_mapped{pool="040000006D039EA000CF32BB000000D868E4C6E2",volume="Volume_2"} 0
-		eseries_volume_mapped{pool="040000006D039EA000CF32BB000000D868E4C6E2",volume="Volume_3"} 0
+		eseries_volume_mapped{pool="040000006D039EA000CF32BB000000D868E4C6E2",volume="Volume_1",workload="Oracle"} 1
+		eseries_volume_mapped{pool="040000006D039EA000CF32BB000000D868E4C6E2",volume="Volume_2",workload="Oracle"} 0
+		eseries_volume_mapped{pool="040000006D039EA000CF32BB000000D868E4C6E2",volume="Volume_3",workload=""} 0
 		# HELP eseries_volume_mappings_total Number of host mappings for this volume
 		# TYPE eseries_volume_mappings_total gauge
-		eseries_volume_mappings_total{pool="040000006D039EA000CF32BB000000D868E4C6E2",volume="Volume_1"} 1
-		eseries_volume_mappings_total{pool="040000006D039EA000CF32BB000000D868E4C6E2",volume="Volume_2"} 0
-		eseries_volume_mappings_total{pool="040000006D039EA000CF32BB000000D868E4C6E2",volume="Volume_3"} 0
+		eseries_volume_mappings_total{pool="040000006D039EA000CF32BB000000D868E4C6E2",volume="Volume_1",workload="Oracle"} 1
+		eseries_volume_mappings_total{pool="040000006D039EA000CF32BB000000D868E4C6E2",volume="Volume_2",workload="Oracle"} 0
+		eseries_volume_mappings_total{pool="040000006D039EA000CF32BB000000D868E4C6E2",volume="Volume_3",workload=""} 0
 		# HELP eseries_volume_offline Whether the volume is offline (1) or online (0)
 		# TYPE eseries_volume_offline gauge
-		eseries_volume_offline{pool="040000006D039EA000CF32BB000000D868E4C6E2",volume="Volume_1"} 0
-		eseries_volume_offline{pool="040000006D039EA000CF32BB000000D868E4C6E2",volume="Volume_2"} 0
-		eseries_volume_offline{pool="040000006D039EA000CF32BB000000D868E4C6E2",volume="Volume_3"} 1
+		eseries_volume_offline{pool="040000006D039EA000CF32BB000000D868E4C6E2",volume="Volume_1",workload="Oracle"} 0
+		eseries_volume_offline{pool="040000006D039EA000CF32BB000000D868E4C6E2",volume="Volume_2",workload="Oracle"} 0
+		eseries_volume_offline{pool="040000006D039EA000CF32BB000000D868E4C6E2",volume="Volume_3",workload=""} 1
 		# HELP eseries_volume_status Status of the volume (1 for optimal, 0 otherwise)
 		# TYPE eseries_volume_status gauge
-		eseries_volume_status{pool="040000006D039EA000CF32BB000000D868E4C6E2",status="optimal",volume="Volume_1"} 1
-		eseries_volume_status{pool="040000006D039EA000CF32BB000000D868E4C6E2",status="optimal",volume="Volume_2"} 1
-		eseries_volume_status{pool="040000006D039EA000CF32BB000000D868E4C6E2",status="failed",volume="Volume_3"} 0
+		eseries_volume_status{pool="040000006D039EA000CF32BB000000D868E4C6E2",status="optimal",volume="Volume_1",workload="Oracle"} 1
+		eseries_volume_status{pool="040000006D039EA000CF32BB000000D868E4C6E2",status="optimal",volume="Volume_2",workload="Oracle"} 1
+		eseries_volume_status{pool="040000006D039EA000CF32BB000000D868E4C6E2",status="failed",volume="Volume_3",workload=""} 0
 		# HELP eseries_volume_thin_provisioned Whether the volume uses thin provisioning (1) or not (0)
 		# TYPE eseries_volume_thin_provisioned gauge
-		eseries_volume_thin_provisioned{pool="040000006D039EA000CF32BB000000D868E4C6E2",volume="Volume_1"} 0
-		eseries_volume_thin_provisioned{pool="040000006D039EA000CF32BB000000D868E4C6E2",volume="Volume_2"} 1
-		eseries_volume_thin_provisioned{pool="040000006D039EA000CF32BB000000D868E4C6E2",volume="Volume_3"} 0
+		eseries_volume_thin_provisioned{pool="040000006D039EA000CF32BB000000D868E4C6E2",volume="Volume_1",workload="Oracle"} 0
+		eseries_volume_thin_provisioned{pool="040000006D039EA000CF32BB000000D868E4C6E2",volume="Volume_2",workload="Oracle"} 1
+		eseries_volume_thin_provisioned{pool="040000006D039EA000CF32BB000000D868E4C6E2",volume="Volume_3",workload=""} 0
+		# HELP eseries_thin_volume_growth_alert_threshold_ratio Repository utilization ratio (0-1) at which the array raises a growth alert
+		# TYPE eseries_thin_volume_growth_alert_threshold_ratio gauge
+		eseries_thin_volume_growth_alert_threshold_ratio{pool="040000006D039EA000CF32BB000000D868E4C6E2",volume="Thin_1",workload="VMware"} 0.95
+		# HELP eseries_thin_volume_provisioned_bytes Virtual capacity presented to hosts by the thin volume in bytes
+		# TYPE eseries_thin_volume_provisioned_bytes gauge
+		eseries_thin_volume_provisioned_bytes{pool="040000006D039EA000CF32BB000000D868E4C6E2",volume="Thin_1",workload="VMware"} 1.099511627776e+13
+		# HELP eseries_thin_volume_quota_bytes Maximum capacity the thin volume repository may grow to in bytes
+		# TYPE eseries_thin_volume_quota_bytes gauge
+		eseries_thin_volume_quota_bytes{pool="040000006D039EA000CF32BB000000D868E4C6E2",volume="Thin_1",workload="VMware"} 5.49755813888e+12
+		# HELP eseries_thin_volume_repository_bytes Pool capacity currently consumed by the thin volume repository in bytes
+		# TYPE eseries_thin_volume_repository_bytes gauge
+		eseries_thin_volume_repository_bytes{pool="040000006D039EA000CF32BB000000D868E4C6E2",volume="Thin_1",workload="VMware"} 1.099511627776e+12
+		# HELP eseries_exporter_collect_error Indicates if error has occurred during collection
+		# TYPE eseries_exporter_collect_error gauge
+		eseries_exporter_collect_error{collector="volumes"} 0
 	`
 
-	if err := testutil.CollectAndCompare(collector, strings.NewReader(expected)); err != nil {
+	if err := testutil.GatherAndCompare(gatherers, strings.NewReader(expected),
+		"eseries_volume_capacity_bytes", "eseries_volume_mapped", "eseries_volume_mappings_total",
+		"eseries_volume_offline", "eseries_volume_status", "eseries_volume_thin_provisioned",
+		"eseries_thin_volume_growth_alert_threshold_ratio", "eseries_thin_volume_provisioned_bytes",
+		"eseries_thin_volume_quota_bytes", "eseries_thin_volume_repository_bytes",
+		"eseries_exporter_collect_error"); err != nil {
+		t.Errorf("Unexpected metrics:\n%v", err)
+	}
+}
+
+func TestVolumesCollectorWorkloadsError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if strings.HasSuffix(req.URL.Path, "thin-volumes") {
+			data, _ := os.ReadFile("testdata/thin-volumes-response.json")
+			rw.Write(data)
+			return
+		}
+		if strings.HasSuffix(req.URL.Path, "workloads") {
+			rw.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		data, _ := os.ReadFile("testdata/volumes-response.json")
+		rw.Write(data)
+	}))
+	defer server.Close()
+
+	baseURL, _ := url.Parse(server.URL)
+	target := config.Target{
+		Name:       "test-array",
+		BaseURL:    baseURL,
+		HttpClient: server.Client(),
+	}
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+	collector := NewVolumesExporter(target, logger)
+
+	expected := `
+		# HELP eseries_volume_mapped Whether the volume is mapped to a host (1) or not (0)
+		# TYPE eseries_volume_mapped gauge
+		eseries_volume_mapped{pool="040000006D039EA000CF32BB000000D868E4C6E2",volume="Volume_1",workload=""} 1
+		eseries_volume_mapped{pool="040000006D039EA000CF32BB000000D868E4C6E2",volume="Volume_2",workload=""} 0
+		eseries_volume_mapped{pool="040000006D039EA000CF32BB000000D868E4C6E2",volume="Volume_3",workload=""} 0
+		# HELP eseries_thin_volume_quota_bytes Maximum capacity the thin volume repository may grow to in bytes
+		# TYPE eseries_thin_volume_quota_bytes gauge
+		eseries_thin_volume_quota_bytes{pool="040000006D039EA000CF32BB000000D868E4C6E2",volume="Thin_1",workload=""} 5.49755813888e+12
+		# HELP eseries_exporter_collect_error Indicates if error has occurred during collection
+		# TYPE eseries_exporter_collect_error gauge
+		eseries_exporter_collect_error{collector="volumes"} 1
+	`
+	gatherers := setupGatherer(collector)
+	if count, err := testutil.GatherAndCount(gatherers); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	} else if count != 24 {
+		t.Errorf("Expected 24 metrics, got %d", count)
+	}
+	if err := testutil.GatherAndCompare(gatherers, strings.NewReader(expected),
+		"eseries_volume_mapped", "eseries_thin_volume_quota_bytes", "eseries_exporter_collect_error"); err != nil {
 		t.Errorf("Unexpected metrics:\n%v", err)
 	}
 }
@@ -94,9 +177,19 @@ func TestVolumesCollectorError(t *testing.T) {
 	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
 	collector := NewVolumesExporter(target, logger)
 
-	// Should return 0 metrics on error
-	count := testutil.CollectAndCount(collector)
-	if count != 0 {
-		t.Errorf("Expected 0 metrics on error, got %d", count)
+	expected := `
+		# HELP eseries_exporter_collect_error Indicates if error has occurred during collection
+		# TYPE eseries_exporter_collect_error gauge
+		eseries_exporter_collect_error{collector="volumes"} 1
+	`
+	// Only the error and duration metrics are returned on error
+	gatherers := setupGatherer(collector)
+	if count, err := testutil.GatherAndCount(gatherers); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	} else if count != 2 {
+		t.Errorf("Expected 2 metrics on error, got %d", count)
+	}
+	if err := testutil.GatherAndCompare(gatherers, strings.NewReader(expected), "eseries_exporter_collect_error"); err != nil {
+		t.Errorf("Unexpected metrics:\n%v", err)
 	}
 }
@@ -0,0 +1,179 @@
+package collector
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/sckyzo/eseries_exporter/internal/config"
+)
+
+type VolumeStatistics struct {
+	VolumeID          string  `json:"volumeId"`
+	ObservedTimeInMS  string  `json:"observedTimeInMS"`
+	ReadIOps          float64 `json:"readIOps"`
+	WriteIOps         float64 `json:"writeIOps"`
+	ReadThroughput    float64 `json:"readThroughput"`
+	WriteThroughput   float64 `json:"writeThroughput"`
+	ReadResponseTime  float64 `json:"readResponseTime"`
+	WriteResponseTime float64 `json:"writeResponseTime"`
+}
+
+// workloadStatistics sums the statistics of the volumes tagged with a workload.
+// Response times are weighted by IOPS so they can be averaged afterwards.
+type workloadStatistics struct {
+	// Observed is the oldest observation time of the summed statistics
+	Observed            time.Time
+	Volumes             float64
+	ReadIOps            float64
+	WriteIOps           float64
+	ReadThroughput      float64
+	WriteThroughput     float64
+	ReadResponseWeight  float64
+	WriteResponseWeight float64
+}
+
+type WorkloadStatisticsCollector struct {
+	Volumes           *prometheus.Desc
+	ReadIOps          *prometheus.Desc
+	WriteIOps         *prometheus.Desc
+	ReadThroughput    *prometheus.Desc
+	WriteThroughput   *prometheus.Desc
+	ReadResponseTime  *prometheus.Desc
+	WriteResponseTime *prometheus.Desc
+	target            config.Target
+	logger            *slog.Logger
+}
+
+func init() {
+	registerCollector("workload-statistics", false, NewWorkloadStatisticsExporter)
+}
+
+func NewWorkloadStatisticsExporter(target config.Target, logger *slog.Logger) Collector {
+	labels := []string{"workload"}
+	return &WorkloadStatisticsCollector{
+		Volumes: prometheus.NewDesc(prometheus.BuildFQName(namespace, "workload", "volumes"),
+			"Number of volumes tagged with the workload that report statistics", labels, nil),
+		ReadIOps: prometheus.NewDesc(prometheus.BuildFQName(namespace, "workload", "read_iops"),
+			"Read operations per second summed over the workload volumes", labels, nil),
+		WriteIOps: prometheus.NewDesc(prometheus.BuildFQName(namespace, "workload", "write_iops"),
+			"Write operations per second summed over the workload volumes", labels, nil),
+		ReadThroughput: prometheus.NewDesc(prometheus.BuildFQName(namespace, "workload", "read_throughput_bytes_per_second"),
+			"Read throughput summed over the workload volumes", labels, nil),
+		WriteThroughput: prometheus.NewDesc(prometheus.BuildFQName(namespace, "workload", "write_throughput_bytes_per_second"),
+			"Write throughput summed over the workload volumes", labels, nil),
+		ReadResponseTime: prometheus.NewDesc(prometheus.BuildFQName(namespace, "workload", "read_response_time_seconds"),
+			"Read response time of the workload volumes averaged by read IOPS", labels, nil),
+		WriteResponseTime: prometheus.NewDesc(prometheus.BuildFQName(namespace, "workload", "write_response_time_seconds"),
+			"Write response time of the workload volumes averaged by write IOPS", labels, nil),
+		target: target,
+		logger: logger,
+	}
+}
+
+func (c *WorkloadStatisticsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.Volumes
+	ch <- c.ReadIOps
+	ch <- c.WriteIOps
+	ch <- c.ReadThroughput
+	ch <- c.WriteThroughput
+	ch <- c.ReadResponseTime
+	ch <- c.WriteResponseTime
+}
+
+func (c *WorkloadStatisticsCollector) Collect(ch chan<- prometheus.Metric) {
+	c.logger.Debug("Collecting workload-statistics metrics")
+	collectTime := time.Now()
+	var errorMetric int
+	observations := newStatisticsObservations(c.target)
+	workloads, err := c.collect(observations)
+	if err != nil {
+		c.logger.Error("Collection failed", "error", err)
+		errorMetric = 1
+	}
+
+	for name, w := range workloads {
+		var readResponseTime, writeResponseTime float64
+		if w.ReadIOps > 0 {
+			readResponseTime = w.ReadResponseWeight / w.ReadIOps
+		}
+		if w.WriteIOps > 0 {
+			writeResponseTime = w.WriteResponseWeight / w.WriteIOps
+		}
+		ch <- observations.metric(w.Observed, prometheus.MustNewConstMetric(c.Volumes, prometheus.GaugeValue, w.Volumes, name))
+		ch <- observations.metric(w.Observed, prometheus.MustNewConstMetric(c.ReadIOps, prometheus.GaugeValue, w.ReadIOps, name))
+		ch <- observations.metric(w.Observed, prometheus.MustNewConstMetric(c.WriteIOps, prometheus.GaugeValue, w.WriteIOps, name))
+		ch <- observations.metric(w.Observed, prometheus.MustNewConstMetric(c.ReadThroughput, prometheus.GaugeValue, w.ReadThroughput, name))
+		ch <- observations.metric(w.Observed, prometheus.MustNewConstMetric(c.WriteThroughput, prometheus.GaugeValue, w.WriteThroughput, name))
+		ch <- observations.metric(w.Observed, prometheus.MustNewConstMetric(c.ReadResponseTime, prometheus.GaugeValue, readResponseTime, name))
+		ch <- observations.metric(w.Observed, prometheus.MustNewConstMetric(c.WriteResponseTime, prometheus.GaugeValue, writeResponseTime, name))
+	}
+	observations.collect(ch, "workload-statistics")
+
+	ch <- prometheus.MustNewConstMetric(collectError, prometheus.GaugeValue, float64(errorMetric), "workload-statistics")
+	ch <- prometheus.MustNewConstMetric(collectDuration, prometheus.GaugeValue, time.Since(collectTime).Seconds(), "workload-statistics")
+}
+
+func (c *WorkloadStatisticsCollector) collect(observations *statisticsObservations) (map[string]*workloadStatistics, error) {
+	var volumes []Volume
+	var thinVolumes []ThinVolume
+	var workloads []Workload
+	var statistics []VolumeStatistics
+	responses := getResponses(c.target, []request{
+		{path: fmt.Sprintf("/devmgr/v2/storage-systems/%s/volumes", c.target.Name)},
+		{path: fmt.Sprintf("/devmgr/v2/storage-systems/%s/thin-volumes", c.target.Name)},
+		{path: fmt.Sprintf("/devmgr/v2/storage-systems/%s/workloads", c.target.Name), optional: true},
+		{path: fmt.Sprintf("/devmgr/v2/storage-systems/%s/analysed-volume-statistics", c.target.Name)},
+	}, c.logger)
+	if err := errors.Join(
+		responses[0].decode(&volumes),
+		responses[1].decode(&thinVolumes),
+		responses[2].decode(&workloads),
+		responses[3].decode(&statistics),
+	); err != nil {
+		return nil, err
+	}
+
+	names := workloadNames(workloads)
+	volumeWorkloads := make(map[string]string)
+	for _, v := range volumes {
+		volumeWorkloads[v.ID] = volumeWorkload(v.Metadata, names)
+	}
+	for _, v := range thinVolumes {
+		volumeWorkloads[v.ID] = volumeWorkload(v.Metadata, names)
+	}
+	// Untagged volumes are not aggregated
+	aggregated := make(map[string]*workloadStatistics)
+	for _, s := range statistics {
+		name := volumeWorkloads[s.VolumeID]
+		if name == "" {
+			continue
+		}
+		observed, fresh := observations.observe(s.ObservedTimeInMS)
+		if !fresh {
+			c.logger.Debug("Skipping stale volume statistics", "volume", s.VolumeID, "observed", observed)
+			continue
+		}
+		w, ok := aggregated[name]
+		if !ok {
+			w = &workloadStatistics{}
+			aggregated[name] = w
+		}
+		if w.Observed.IsZero() || observed.Before(w.Observed) {
+			w.Observed = observed
+		}
+		w.Volumes++
+		w.ReadIOps += s.ReadIOps
+		w.WriteIOps += s.WriteIOps
+		// Convert MiB/s to bytes/s
+		w.ReadThroughput += s.ReadThroughput * 1024 * 1024
+		w.WriteThroughput += s.WriteThroughput * 1024 * 1024
+		// Convert milliseconds to seconds
+		w.ReadResponseWeight += s.ReadResponseTime * 0.001 * s.ReadIOps
+		w.WriteResponseWeight += s.WriteResponseTime * 0.001 * s.WriteIOps
+	}
+	return aggregated, nil
+}
@@ -0,0 +1,180 @@
+package collector
+
+import (
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/sckyzo/eseries_exporter/internal/config"
+)
+
+func TestWorkloadStatisticsCollector(t *testing.T) {
+	fixtures := make(map[string][]byte)
+	for path, file := range map[string]string{
+		"volumes":                    "testdata/volumes-response.json",
+		"thin-volumes":               "testdata/thin-volumes-response.json",
+		"workloads":                  "testdata/workloads.json",
+		"analysed-volume-statistics": "testdata/analysed-volume-statistics.json",
+	} {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatalf("Error loading fixture data: %s", err.Error())
+		}
+		fixtures[path] = data
+	}
+	expected := `# HELP eseries_workload_volumes Number of volumes tagged with the workload that report statistics
+# TYPE eseries_workload_volumes gauge
+eseries_workload_volumes{workload="Oracle"} 2
+eseries_workload_volumes{workload="VMware"} 1
+# HELP eseries_workload_read_iops Read operations per second summed over the workload volumes
+# TYPE eseries_workload_read_iops gauge
+eseries_workload_read_iops{workload="Oracle"} 4000
+eseries_workload_read_iops{workload="VMware"} 200
+# HELP eseries_workload_write_iops Write operations per second summed over the workload volumes
+# TYPE eseries_workload_write_iops gauge
+eseries_workload_write_iops{workload="Oracle"} 1000
+eseries_workload_write_iops{workload="VMware"} 100
+# HELP eseries_workload_read_throughput_bytes_per_second Read throughput summed over the workload volumes
+# TYPE eseries_workload_read_throughput_bytes_per_second gauge
+eseries_workload_read_throughput_bytes_per_second{workload="Oracle"} 1.048576e+08
+eseries_workload_read_throughput_bytes_per_second{workload="VMware"} 1.048576e+07
+# HELP eseries_workload_write_throughput_bytes_per_second Write throughput summed over the workload volumes
+# TYPE eseries_workload_write_throughput_bytes_per_second gauge
+eseries_workload_write_throughput_bytes_per_second{workload="Oracle"} 3.145728e+07
+eseries_workload_write_throughput_bytes_per_second{workload="VMware"} 5.24288e+06
+# HELP eseries_workload_read_response_time_seconds Read response time of the workload volumes averaged by read IOPS
+# TYPE eseries_workload_read_response_time_seconds gauge
+eseries_workload_read_response_time_seconds{workload="Oracle"} 0.0035
+eseries_workload_read_response_time_seconds{workload="VMware"} 0.005
+# HELP eseries_workload_write_response_time_seconds Write response time of the workload volumes averaged by write IOPS
+# TYPE eseries_workload_write_response_time_seconds gauge
+eseries_workload_write_response_time_seconds{workload="Oracle"} 0.002
+eseries_workload_write_response_time_seconds{workload="VMware"} 0.002
+# HELP eseries_exporter_collect_error Indicates if error has occurred during collection
+# TYPE eseries_exporter_collect_error gauge
+eseries_exporter_collect_error{collector="workload-statistics"} 0
+`
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		path := strings.TrimPrefix(req.URL.Path, "/devmgr/v2/storage-systems/test/")
+		data, ok := fixtures[path]
+		if !ok {
+			http.Error(rw, "not found", http.StatusNotFound)
+			return
+		}
+		_, _ = rw.Write(data)
+	}))
+	defer server.Close()
+	baseURL, _ := url.Parse(server.URL)
+	target := config.Target{
+		Name:       "test",
+		User:       "test",
+		Password:   "test",
+		BaseURL:    baseURL,
+		HttpClient: &http.Client{},
+	}
+	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+	collector := NewWorkloadStatisticsExporter(target, logger)
+	gatherers := setupGatherer(collector)
+	if val, err := testutil.GatherAndCount(gatherers); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	} else if val != 17 {
+		t.Errorf("Unexpected collection count %d, expected 17", val)
+	}
+	if err := testutil.GatherAndCompare(gatherers, strings.NewReader(expected),
+		"eseries_workload_volumes", "eseries_workload_read_iops", "eseries_workload_write_iops",
+		"eseries_workload_read_throughput_bytes_per_second", "eseries_workload_write_throughput_bytes_per_second",
+		"eseries_workload_read_response_time_seconds", "eseries_workload_write_response_time_seconds",
+		"eseries_exporter_collect_error"); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+}
+
+func TestWorkloadStatisticsCollectorError(t *testing.T) {
+	expected := `# HELP eseries_exporter_collect_error Indicates if error has occurred during collection
+# TYPE eseries_exporter_collect_error gauge
+eseries_exporter_collect_error{collector="workload-statistics"} 1
+`
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		http.Error(rw, "error", http.StatusNotFound)
+	}))
+	defer server.Close()
+	baseURL, _ := url.Parse(server.URL)
+	target := config.Target{
+		Name:       "test",
+		User:       "test",
+		Password:   "test",
+		BaseURL:    baseURL,
+		HttpClient: &http.Client{},
+	}
+	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+	collector := NewWorkloadStatisticsExporter(target, logger)
+	gatherers := setupGatherer(collector)
+	if val, err := testutil.GatherAndCount(gatherers); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	} else if val != 2 {
+		t.Errorf("Unexpected collection count %d, expected 2", val)
+	}
+	if err := testutil.GatherAndCompare(gatherers, strings.NewReader(expected),
+		"eseries_workload_volumes", "eseries_exporter_collect_error"); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+}
+
+func TestWorkloadStatisticsCollectorNoWorkloads(t *testing.T) {
+	fixtures := make(map[string][]byte)
+	for path, file := range map[string]string{
+		"volumes":                    "testdata/volumes-response.json",
+		"thin-volumes":               "testdata/thin-volumes-response.json",
+		"analysed-volume-statistics": "testdata/analysed-volume-statistics.json",
+	} {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatalf("Error loading fixture data: %s", err.Error())
+		}
+		fixtures[path] = data
+	}
+	expected := `# HELP eseries_workload_volumes Number of volumes tagged with the workload that report statistics
+# TYPE eseries_workload_volumes gauge
+eseries_workload_volumes{workload="4200000001000000000000000000000000000000"} 2
+eseries_workload_volumes{workload="4200000002000000000000000000000000000000"} 1
+# HELP eseries_exporter_collect_error Indicates if error has occurred during collection
+# TYPE eseries_exporter_collect_error gauge
+eseries_exporter_collect_error{collector="workload-statistics"} 0
+`
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		path := strings.TrimPrefix(req.URL.Path, "/devmgr/v2/storage-systems/test/")
+		data, ok := fixtures[path]
+		if !ok {
+			http.Error(rw, "not found", http.StatusNotFound)
+			return
+		}
+		_, _ = rw.Write(data)
+	}))
+	defer server.Close()
+	baseURL, _ := url.Parse(server.URL)
+	target := config.Target{
+		Name:       "test",
+		User:       "test",
+		Password:   "test",
+		BaseURL:    baseURL,
+		HttpClient: &http.Client{},
+	}
+	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+	collector := NewWorkloadStatisticsExporter(target, logger)
+	gatherers := setupGatherer(collector)
+	if val, err := testutil.GatherAndCount(gatherers); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	} else if val != 17 {
+		t.Errorf("Unexpected collection count %d, expected 17", val)
+	}
+	if err := testutil.GatherAndCompare(gatherers, strings.NewReader(expected),
+		"eseries_workload_volumes", "eseries_exporter_collect_error"); err != nil {
+		t.Errorf("unexpected collecting result:\n%s", err)
+	}
+}
@@ -18,13 +18,49 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"strings"
 	"sync"
+	"time"
 
 	yaml "gopkg.in/yaml.v2"
 )
 
+// DefaultCapacityForecastLookback is used when a module or target does not set
+// a capacity forecast lookback.
+const DefaultCapacityForecastLookback = 7 * 24 * time.Hour
+
+// ComponentNames are the keys accepted in a module's component_statuses, the
+// names of the status components exported by the collectors.
+var ComponentNames = []string{"battery", "fan", "power_supply", "cache_memory_dimm", "thermal_sensor", "drive"}
+
 type Config struct {
 	Modules map[string]*Module `yaml:"modules"`
+	// OTLP enables pushing the configured targets to an OTLP/HTTP endpoint.
+	OTLP *OTLP `yaml:"otlp"`
+}
+
+// OTLP configures the push mode, which collects targets on a schedule and sends
+// the results to an OpenTelemetry collector.
+type OTLP struct {
+	// Endpoint is the OTLP/HTTP metrics URL, e.g. http://collector:4318/v1/metrics
+	Endpoint string            `yaml:"endpoint"`
+	Headers  map[string]string `yaml:"headers"`
+	Interval time.Duration     `yaml:"interval"`
+	Timeout  time.Duration     `yaml:"timeout"`
+	// Retries is the number of times a failed push is retried before it is
+	// buffered for the next interval, 0 disables retries. Unset defaults to 3.
+	Retries *int `yaml:"retries"`
+	// BufferSize is the number of failed pushes kept while the endpoint is
+	// unavailable, the oldest are dropped first. Unset or 0 defaults to 10.
+	BufferSize int `yaml:"buffer_size"`
+	// ResourceAttributes are added to the resource of every storage system.
+	ResourceAttributes map[string]string `yaml:"resource_attributes"`
+	Targets            []OTLPTarget      `yaml:"targets"`
+}
+
+type OTLPTarget struct {
+	Target string `yaml:"target"`
+	Module string `yaml:"module"`
 }
 
 type SafeConfig struct {
@@ -40,16 +76,50 @@ type Module struct {
 	Timeout     int      `yaml:"timeout"`
 	InsecureSSL bool     `yaml:"insecure_ssl"`
 	RootCA      string   `yaml:"root_ca"`
+	// ComponentStatuses adds statuses, keyed by component (e.g. battery, drive),
+	// to the ones the exporter already knows about.
+	ComponentStatuses map[string][]string `yaml:"component_statuses"`
+	// CapacityForecastLookback is the window of pool usage history used by the
+	// capacity-forecast collector.
+	CapacityForecastLookback time.Duration `yaml:"capacity_forecast_lookback"`
+	// StatisticsTimestamps exports analysed statistics with the time the proxy
+	// observed them instead of the scrape time.
+	StatisticsTimestamps bool `yaml:"statistics_timestamps"`
+	// StatisticsMaxAge suppresses analysed statistics observed longer ago than
+	// this, zero exports them regardless of age.
+	StatisticsMaxAge time.Duration `yaml:"statistics_max_age"`
+	// DriveOutlierThreshold is the score above which the drive-outliers collector
+	// flags a drive as slower than its peers.
+	DriveOutlierThreshold float64 `yaml:"drive_outlier_threshold"`
+	// ProxyConcurrency bounds the storage systems collected in parallel when
+	// scraping every system of the proxy with target=*.
+	ProxyConcurrency int `yaml:"proxy_concurrency"`
 }
 
 type Target struct {
-	Name       string
-	User       string
-	Password   string
-	ProxyURL   string
-	Collectors []string
-	BaseURL    *url.URL
-	HttpClient *http.Client
+	Name              string
+	User              string
+	Password          string
+	ProxyURL          string
+	Collectors        []string
+	ComponentStatuses map[string][]string
+	// CapacityForecastLookback is the window of pool usage history used for forecasts
+	CapacityForecastLookback time.Duration
+	// StatisticsTimestamps stamps analysed statistics with their observation time
+	StatisticsTimestamps bool
+	// StatisticsMaxAge suppresses analysed statistics older than this when non-zero
+	StatisticsMaxAge time.Duration
+	// DriveOutlierThreshold is the score above which a drive is flagged as an outlier
+	DriveOutlierThreshold float64
+	// ProxyConcurrency bounds the storage systems collected in parallel for target=*
+	ProxyConcurrency int
+	// OpenMetrics is set when the scrape negotiated the OpenMetrics format
+	OpenMetrics bool
+	// CreatedTimestamps is set when the output can carry the created timestamp of
+	// counters, OpenMetrics scrapes and OTLP pushes
+	CreatedTimestamps bool
+	BaseURL           *url.URL
+	HttpClient        *http.Client
 }
 
 func (sc *SafeConfig) ReloadConfig(configFile string) error {
@@ -71,6 +141,15 @@ func (sc *SafeConfig) ReloadConfig(configFile string) error {
 		if module.Timeout == 0 {
 			module.Timeout = 10
 		}
+		if module.CapacityForecastLookback == 0 {
+			module.CapacityForecastLookback = DefaultCapacityForecastLookback
+		}
+		if module.DriveOutlierThreshold == 0 {
+			module.DriveOutlierThreshold = 3.5
+		}
+		if module.ProxyConcurrency == 0 {
+			module.ProxyConcurrency = 4
+		}
 		if module.ProxyURL == "" {
 			return fmt.Errorf("Module %s must define 'proxy_url' value", key)
 		}
@@ -80,10 +159,74 @@ func (sc *SafeConfig) ReloadConfig(configFile string) error {
 		if module.Password == "" {
 			return fmt.Errorf("Module %s must define 'password' value", key)
 		}
+		for component, statuses := range module.ComponentStatuses {
+			if !knownComponent(component) {
+				return fmt.Errorf("Module %s component_statuses %s is not a known component, expected one of %s",
+					key, component, strings.Join(ComponentNames, ", "))
+			}
+			for _, status := range statuses {
+				// Every component already exports an unknown bucket
+				if strings.EqualFold(status, "unknown") {
+					return fmt.Errorf("Module %s component_statuses %s must not list 'unknown'", key, component)
+				}
+			}
+		}
 		c.Modules[key] = module
 	}
+	if c.OTLP != nil {
+		if err := c.OTLP.validate(c.Modules); err != nil {
+			return err
+		}
+	}
 	sc.Lock()
 	sc.C = c
 	sc.Unlock()
 	return nil
 }
+
+func knownComponent(name string) bool {
+	for _, c := range ComponentNames {
+		if c == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (o *OTLP) validate(modules map[string]*Module) error {
+	if o.Endpoint == "" {
+		return fmt.Errorf("OTLP must define 'endpoint' value")
+	}
+	if len(o.Targets) == 0 {
+		return fmt.Errorf("OTLP must define at least one target")
+	}
+	if o.Interval < 0 || o.Timeout < 0 || o.BufferSize < 0 || (o.Retries != nil && *o.Retries < 0) {
+		return fmt.Errorf("OTLP interval, timeout, retries and buffer_size must not be negative")
+	}
+	if o.Interval == 0 {
+		o.Interval = time.Minute
+	}
+	if o.Timeout == 0 {
+		o.Timeout = 10 * time.Second
+	}
+	if o.Retries == nil {
+		retries := 3
+		o.Retries = &retries
+	}
+	if o.BufferSize == 0 {
+		o.BufferSize = 10
+	}
+	for i := range o.Targets {
+		target := &o.Targets[i]
+		if target.Target == "" {
+			return fmt.Errorf("OTLP target %d must define 'target' value", i)
+		}
+		if target.Module == "" {
+			target.Module = "default"
+		}
+		if _, ok := modules[target.Module]; !ok {
+			return fmt.Errorf("OTLP target %s uses unknown module %s", target.Target, target.Module)
+		}
+	}
+	return nil
+}
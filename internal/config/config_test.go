@@ -15,6 +15,7 @@ package config
 
 import (
 	"testing"
+	"time"
 )
 
 func TestReloadConfigDefaults(t *testing.T) {
@@ -32,6 +33,43 @@ func TestReloadConfigDefaults(t *testing.T) {
 	if module.User != "monitor" {
 		t.Errorf("Module User does not match monitor")
 	}
+	if statuses := module.ComponentStatuses["battery"]; len(statuses) != 1 || statuses[0] != "newState" {
+		t.Errorf("Module ComponentStatuses battery does not match [newState], got %v", statuses)
+	}
+	if module.CapacityForecastLookback != 72*time.Hour {
+		t.Errorf("Module CapacityForecastLookback does not match 72h, got %s", module.CapacityForecastLookback)
+	}
+	if !module.StatisticsTimestamps {
+		t.Errorf("Module StatisticsTimestamps not enabled")
+	}
+	if module.StatisticsMaxAge != 5*time.Minute {
+		t.Errorf("Module StatisticsMaxAge does not match 5m, got %s", module.StatisticsMaxAge)
+	}
+	if module.DriveOutlierThreshold != 3.5 {
+		t.Errorf("Module DriveOutlierThreshold does not match default 3.5, got %v", module.DriveOutlierThreshold)
+	}
+	if module.ProxyConcurrency != 4 {
+		t.Errorf("Module ProxyConcurrency does not match default 4, got %d", module.ProxyConcurrency)
+	}
+	if sc.C.OTLP == nil {
+		t.Fatalf("OTLP not loaded")
+	}
+	if sc.C.OTLP.Interval != time.Minute || sc.C.OTLP.Timeout != 10*time.Second || *sc.C.OTLP.Retries != 3 || sc.C.OTLP.BufferSize != 10 {
+		t.Errorf("OTLP defaults not applied, got %+v", sc.C.OTLP)
+	}
+	if len(sc.C.OTLP.Targets) != 1 || sc.C.OTLP.Targets[0].Module != "default" {
+		t.Errorf("OTLP target module does not match default, got %+v", sc.C.OTLP.Targets)
+	}
+}
+
+func TestReloadConfigOTLPNoRetries(t *testing.T) {
+	sc := &SafeConfig{}
+	if err := sc.ReloadConfig("testdata/otlp-no-retries.yaml"); err != nil {
+		t.Fatalf("Unexpected err: %s", err.Error())
+	}
+	if *sc.C.OTLP.Retries != 0 {
+		t.Errorf("OTLP Retries does not match 0, got %d", *sc.C.OTLP.Retries)
+	}
 }
 
 func TestReloadConfigBadConfigs(t *testing.T) {
@@ -60,6 +98,22 @@ func TestReloadConfigBadConfigs(t *testing.T) {
 			ConfigFile:    "testdata/missing-password.yaml",
 			ExpectedError: "Module default must define 'password' value",
 		},
+		{
+			ConfigFile:    "testdata/unknown-component-status.yaml",
+			ExpectedError: "Module default component_statuses battery must not list 'unknown'",
+		},
+		{
+			ConfigFile:    "testdata/unknown-component.yaml",
+			ExpectedError: "Module default component_statuses batery is not a known component, expected one of battery, fan, power_supply, cache_memory_dimm, thermal_sensor, drive",
+		},
+		{
+			ConfigFile:    "testdata/otlp-unknown-module.yaml",
+			ExpectedError: "OTLP target array1 uses unknown module dne",
+		},
+		{
+			ConfigFile:    "testdata/otlp-negative-retries.yaml",
+			ExpectedError: "OTLP interval, timeout, retries and buffer_size must not be negative",
+		},
 	}
 	for i, test := range tests {
 		err := sc.ReloadConfig(test.ConfigFile)
@@ -0,0 +1,231 @@
+package otlp
+
+import (
+	"encoding/json"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/version"
+)
+
+// The types below follow the JSON encoding of the OTLP ExportMetricsServiceRequest,
+// limited to the fields the exporter fills.
+
+const aggregationTemporalityCumulative = 2
+
+type exportRequest struct {
+	ResourceMetrics []*resourceMetrics `json:"resourceMetrics"`
+}
+
+type resourceMetrics struct {
+	Resource     resource       `json:"resource"`
+	ScopeMetrics []scopeMetrics `json:"scopeMetrics"`
+}
+
+type resource struct {
+	Attributes []keyValue `json:"attributes"`
+}
+
+type scopeMetrics struct {
+	Scope   scope     `json:"scope"`
+	Metrics []*metric `json:"metrics"`
+}
+
+type scope struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+}
+
+type metric struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Unit        string `json:"unit,omitempty"`
+	Gauge       *gauge `json:"gauge,omitempty"`
+	Sum         *sum   `json:"sum,omitempty"`
+}
+
+type gauge struct {
+	DataPoints []dataPoint `json:"dataPoints"`
+}
+
+type sum struct {
+	DataPoints             []dataPoint `json:"dataPoints"`
+	AggregationTemporality int         `json:"aggregationTemporality"`
+	IsMonotonic            bool        `json:"isMonotonic"`
+}
+
+type dataPoint struct {
+	Attributes        []keyValue `json:"attributes,omitempty"`
+	StartTimeUnixNano string     `json:"startTimeUnixNano,omitempty"`
+	TimeUnixNano      string     `json:"timeUnixNano"`
+	AsDouble          double     `json:"asDouble"`
+}
+
+type keyValue struct {
+	Key   string   `json:"key"`
+	Value anyValue `json:"value"`
+}
+
+type anyValue struct {
+	StringValue string `json:"stringValue"`
+}
+
+// double encodes non-finite values as the strings used by the protobuf JSON mapping.
+type double float64
+
+func (d double) MarshalJSON() ([]byte, error) {
+	f := float64(d)
+	switch {
+	case math.IsNaN(f):
+		return []byte(`"NaN"`), nil
+	case math.IsInf(f, 1):
+		return []byte(`"Infinity"`), nil
+	case math.IsInf(f, -1):
+		return []byte(`"-Infinity"`), nil
+	}
+	return json.Marshal(f)
+}
+
+// units maps metric name suffixes to UCUM units, longest first.
+var units = []struct {
+	suffix string
+	unit   string
+}{
+	{"_bytes_per_second", "By/s"},
+	{"_seconds", "s"},
+	{"_bytes", "By"},
+	{"_ratio", "1"},
+	{"_celsius", "Cel"},
+	{"_watts", "W"},
+	{"_rpm", "{rpm}"},
+}
+
+// Labels that identify the storage system when a proxy target collects several,
+// they are moved from the data points to the resource.
+var systemLabels = map[string]string{
+	"system_id":   "eseries.system.id",
+	"system_name": "eseries.system.name",
+}
+
+// encode builds the request for the batches. Counters become cumulative monotonic
+// sums without their _total suffix, gauges and untyped metrics become gauges and
+// other types are skipped. Samples without a timestamp are stamped with now.
+func encode(batches []Batch, resourceAttributes map[string]string, now time.Time) ([]byte, error) {
+	request := exportRequest{}
+	for _, batch := range batches {
+		resources := make(map[string]*resourceMetrics)
+		for _, family := range batch.Families {
+			for _, m := range family.GetMetric() {
+				var value float64
+				switch family.GetType() {
+				case dto.MetricType_COUNTER:
+					value = m.GetCounter().GetValue()
+				case dto.MetricType_GAUGE:
+					value = m.GetGauge().GetValue()
+				case dto.MetricType_UNTYPED:
+					value = m.GetUntyped().GetValue()
+				default:
+					continue
+				}
+				system := make(map[string]string)
+				point := dataPoint{
+					TimeUnixNano: timeUnixNano(now),
+					AsDouble:     double(value),
+				}
+				if m.TimestampMs != nil {
+					point.TimeUnixNano = timeUnixNano(time.UnixMilli(m.GetTimestampMs()))
+				}
+				if created := m.GetCounter().GetCreatedTimestamp(); created != nil {
+					point.StartTimeUnixNano = timeUnixNano(created.AsTime())
+				}
+				for _, l := range m.GetLabel() {
+					if key, ok := systemLabels[l.GetName()]; ok {
+						system[key] = l.GetValue()
+						continue
+					}
+					point.Attributes = append(point.Attributes, stringKeyValue(l.GetName(), l.GetValue()))
+				}
+				key := system["eseries.system.id"] + "\xff" + system["eseries.system.name"]
+				rm, ok := resources[key]
+				if !ok {
+					rm = newResourceMetrics(batch.Attributes, resourceAttributes, system)
+					resources[key] = rm
+					request.ResourceMetrics = append(request.ResourceMetrics, rm)
+				}
+				rm.add(family, point)
+			}
+		}
+	}
+	return json.Marshal(request)
+}
+
+func newResourceMetrics(attributeSets ...map[string]string) *resourceMetrics {
+	attributes := map[string]string{
+		"service.name":    "eseries_exporter",
+		"service.version": version.Version,
+	}
+	for _, set := range attributeSets {
+		for k, v := range set {
+			attributes[k] = v
+		}
+	}
+	keys := make([]string, 0, len(attributes))
+	for k := range attributes {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	rm := &resourceMetrics{ScopeMetrics: []scopeMetrics{{
+		Scope: scope{Name: "github.com/sckyzo/eseries_exporter", Version: version.Version},
+	}}}
+	for _, k := range keys {
+		rm.Resource.Attributes = append(rm.Resource.Attributes, stringKeyValue(k, attributes[k]))
+	}
+	return rm
+}
+
+// add appends point to the metric of family, creating it on first use.
+func (rm *resourceMetrics) add(family *dto.MetricFamily, point dataPoint) {
+	sm := &rm.ScopeMetrics[0]
+	name := family.GetName()
+	if family.GetType() == dto.MetricType_COUNTER {
+		name = strings.TrimSuffix(name, "_total")
+	}
+	var m *metric
+	if n := len(sm.Metrics); n > 0 && sm.Metrics[n-1].Name == name {
+		m = sm.Metrics[n-1]
+	} else {
+		m = &metric{Name: name, Description: family.GetHelp(), Unit: unit(name)}
+		if family.GetType() == dto.MetricType_COUNTER {
+			m.Sum = &sum{AggregationTemporality: aggregationTemporalityCumulative, IsMonotonic: true}
+		} else {
+			m.Gauge = &gauge{}
+		}
+		sm.Metrics = append(sm.Metrics, m)
+	}
+	if m.Sum != nil {
+		m.Sum.DataPoints = append(m.Sum.DataPoints, point)
+	} else {
+		m.Gauge.DataPoints = append(m.Gauge.DataPoints, point)
+	}
+}
+
+func unit(name string) string {
+	for _, u := range units {
+		if strings.HasSuffix(name, u.suffix) {
+			return u.unit
+		}
+	}
+	return ""
+}
+
+func stringKeyValue(key string, value string) keyValue {
+	return keyValue{Key: key, Value: anyValue{StringValue: value}}
+}
+
+func timeUnixNano(t time.Time) string {
+	return strconv.FormatInt(t.UnixNano(), 10)
+}
@@ -0,0 +1,141 @@
+// Package otlp pushes gathered metric families to an OpenTelemetry collector
+// with the JSON encoding of OTLP/HTTP.
+package otlp
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+
+	"github.com/sckyzo/eseries_exporter/internal/config"
+)
+
+// Batch is the families gathered from one target, with the resource attributes
+// describing it.
+type Batch struct {
+	Attributes map[string]string
+	Families   []*dto.MetricFamily
+}
+
+// permanentError is a push rejected by the endpoint, retrying it cannot succeed.
+type permanentError struct {
+	err error
+}
+
+func (e permanentError) Error() string {
+	return e.err.Error()
+}
+
+// Exporter sends pushes to the OTLP endpoint. Pushes that fail after retries are
+// buffered and sent before the next one. It is not safe for concurrent use.
+type Exporter struct {
+	config  *config.OTLP
+	client  *http.Client
+	buffer  [][]byte
+	backoff time.Duration
+	logger  *slog.Logger
+}
+
+func NewExporter(c *config.OTLP, logger *slog.Logger) *Exporter {
+	return &Exporter{
+		config:  c,
+		client:  &http.Client{Timeout: c.Timeout},
+		backoff: time.Second,
+		logger:  logger,
+	}
+}
+
+// Run pushes the batches returned by gather every interval until ctx is done.
+func (e *Exporter) Run(ctx context.Context, gather func() []Batch) {
+	ticker := time.NewTicker(e.config.Interval)
+	defer ticker.Stop()
+	for {
+		if err := e.Push(ctx, gather(), time.Now()); err != nil {
+			e.logger.Error("OTLP push failed", "endpoint", e.config.Endpoint, "buffered", len(e.buffer), "error", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Push encodes the batches and sends them after any buffered pushes. A push
+// that still fails after the configured retries stays buffered.
+func (e *Exporter) Push(ctx context.Context, batches []Batch, now time.Time) error {
+	body, err := encode(batches, e.config.ResourceAttributes, now)
+	if err != nil {
+		return err
+	}
+	e.buffer = append(e.buffer, body)
+	if dropped := len(e.buffer) - e.config.BufferSize; dropped > 0 {
+		e.logger.Warn("OTLP buffer full, dropping oldest pushes", "dropped", dropped)
+		e.buffer = e.buffer[dropped:]
+	}
+	for len(e.buffer) > 0 {
+		err := e.sendWithRetry(ctx, e.buffer[0])
+		var permanent permanentError
+		if errors.As(err, &permanent) {
+			e.logger.Error("OTLP push rejected, dropping it", "endpoint", e.config.Endpoint, "error", err)
+		} else if err != nil {
+			return err
+		}
+		e.buffer = e.buffer[1:]
+	}
+	return nil
+}
+
+func (e *Exporter) sendWithRetry(ctx context.Context, body []byte) error {
+	var err error
+	for attempt := 0; attempt <= *e.config.Retries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(e.backoff << (attempt - 1)):
+			}
+		}
+		err = e.send(ctx, body)
+		var permanent permanentError
+		if err == nil || errors.As(err, &permanent) {
+			return err
+		}
+		e.logger.Debug("OTLP push failed, retrying", "attempt", attempt+1, "error", err)
+	}
+	return err
+}
+
+func (e *Exporter) send(ctx context.Context, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.config.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return permanentError{err}
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range e.config.Headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return nil
+	// Throttling and unavailability are retryable per the OTLP specification
+	case resp.StatusCode == http.StatusTooManyRequests, resp.StatusCode == http.StatusBadGateway,
+		resp.StatusCode == http.StatusServiceUnavailable, resp.StatusCode == http.StatusGatewayTimeout:
+		return fmt.Errorf("%s: %s", resp.Status, respBody)
+	default:
+		return permanentError{fmt.Errorf("%s: %s", resp.Status, respBody)}
+	}
+}
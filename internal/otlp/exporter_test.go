@@ -0,0 +1,235 @@
+package otlp
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+
+	"github.com/sckyzo/eseries_exporter/internal/config"
+)
+
+func (d *double) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err == nil {
+		f, err := strconv.ParseFloat(strings.Replace(s, "Infinity", "Inf", 1), 64)
+		*d = double(f)
+		return err
+	}
+	var f float64
+	err := json.Unmarshal(b, &f)
+	*d = double(f)
+	return err
+}
+
+// receiver is a stand-in for an OTLP/HTTP receiver answering with the queued
+// status codes, then 200.
+type receiver struct {
+	sync.Mutex
+	statuses []int
+	requests []exportRequest
+	headers  []http.Header
+}
+
+func (r *receiver) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	r.Lock()
+	defer r.Unlock()
+	if len(r.statuses) > 0 {
+		status := r.statuses[0]
+		r.statuses = r.statuses[1:]
+		http.Error(rw, http.StatusText(status), status)
+		return
+	}
+	body, _ := io.ReadAll(req.Body)
+	var request exportRequest
+	if err := json.Unmarshal(body, &request); err != nil {
+		http.Error(rw, err.Error(), http.StatusBadRequest)
+		return
+	}
+	r.requests = append(r.requests, request)
+	r.headers = append(r.headers, req.Header)
+}
+
+func testExporter(endpoint string) *Exporter {
+	retries := 1
+	e := NewExporter(&config.OTLP{
+		Endpoint:           endpoint,
+		Headers:            map[string]string{"Authorization": "Bearer test"},
+		Timeout:            time.Second,
+		Retries:            &retries,
+		BufferSize:         2,
+		ResourceAttributes: map[string]string{"deployment.environment": "test"},
+	}, slog.New(slog.NewTextHandler(os.Stderr, nil)))
+	e.backoff = time.Millisecond
+	return e
+}
+
+func testBatches(t *testing.T) []Batch {
+	registry := prometheus.NewRegistry()
+	status := prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: "eseries_storage_system_status", Help: "Storage System status"},
+		[]string{"status", "system_id", "system_name"})
+	status.WithLabelValues("optimal", "array1", "Array 1").Set(1)
+	status.WithLabelValues("optimal", "array2", "Array 2").Set(0)
+	readBytes := prometheus.NewCounterVec(prometheus.CounterOpts{Name: "eseries_drive_read_bytes_total", Help: "Drive statistic readBytes"},
+		[]string{"tray", "slot", "system_id", "system_name"})
+	readBytes.WithLabelValues("0", "1", "array1", "Array 1").Add(1024)
+	registry.MustRegister(status, readBytes)
+	families, err := registry.Gather()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	return []Batch{{Attributes: map[string]string{"eseries.module": "default"}, Families: families}}
+}
+
+func attributes(kvs []keyValue) map[string]string {
+	m := make(map[string]string)
+	for _, kv := range kvs {
+		m[kv.Key] = kv.Value.StringValue
+	}
+	return m
+}
+
+func TestPush(t *testing.T) {
+	r := &receiver{}
+	server := httptest.NewServer(r)
+	defer server.Close()
+	now := time.Unix(1700000000, 0)
+	if err := testExporter(server.URL).Push(context.Background(), testBatches(t), now); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(r.requests) != 1 {
+		t.Fatalf("Unexpected requests %d, expected 1", len(r.requests))
+	}
+	if auth := r.headers[0].Get("Authorization"); auth != "Bearer test" {
+		t.Errorf("Unexpected Authorization header %q", auth)
+	}
+	resources := r.requests[0].ResourceMetrics
+	if len(resources) != 2 {
+		t.Fatalf("Unexpected resources %d, expected 2", len(resources))
+	}
+	resource := attributes(resources[0].Resource.Attributes)
+	for k, v := range map[string]string{
+		"service.name":           "eseries_exporter",
+		"eseries.module":         "default",
+		"eseries.system.id":      "array1",
+		"eseries.system.name":    "Array 1",
+		"deployment.environment": "test",
+	} {
+		if resource[k] != v {
+			t.Errorf("Unexpected resource attribute %s=%q, expected %q", k, resource[k], v)
+		}
+	}
+	metrics := resources[0].ScopeMetrics[0].Metrics
+	if len(metrics) != 2 {
+		t.Fatalf("Unexpected metrics %d, expected 2", len(metrics))
+	}
+	sum := metrics[0]
+	if sum.Name != "eseries_drive_read_bytes" || sum.Unit != "By" || sum.Sum == nil {
+		t.Fatalf("Unexpected sum %+v", sum)
+	}
+	if !sum.Sum.IsMonotonic || sum.Sum.AggregationTemporality != aggregationTemporalityCumulative {
+		t.Errorf("Unexpected sum temporality %+v", sum.Sum)
+	}
+	point := sum.Sum.DataPoints[0]
+	if point.AsDouble != 1024 || point.TimeUnixNano != "1700000000000000000" {
+		t.Errorf("Unexpected sum data point %+v", point)
+	}
+	if attrs := attributes(point.Attributes); len(attrs) != 2 || attrs["tray"] != "0" || attrs["slot"] != "1" {
+		t.Errorf("Unexpected data point attributes %v", attrs)
+	}
+	gauge := metrics[1]
+	if gauge.Name != "eseries_storage_system_status" || gauge.Gauge == nil || gauge.Gauge.DataPoints[0].AsDouble != 1 {
+		t.Errorf("Unexpected gauge %+v", gauge)
+	}
+	if id := attributes(resources[1].Resource.Attributes)["eseries.system.id"]; id != "array2" {
+		t.Errorf("Unexpected second resource %s, expected array2", id)
+	}
+}
+
+func TestPushRetryAndBuffer(t *testing.T) {
+	// The first push fails both attempts and is buffered, the second succeeds
+	// after a retry and sends the buffered push first
+	r := &receiver{statuses: []int{http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusTooManyRequests}}
+	server := httptest.NewServer(r)
+	defer server.Close()
+	e := testExporter(server.URL)
+	if err := e.Push(context.Background(), testBatches(t), time.Unix(1700000000, 0)); err == nil {
+		t.Fatalf("Expected error pushing to unavailable endpoint")
+	}
+	if len(e.buffer) != 1 {
+		t.Errorf("Unexpected buffer length %d, expected 1", len(e.buffer))
+	}
+	if err := e.Push(context.Background(), testBatches(t), time.Unix(1700000060, 0)); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(e.buffer) != 0 {
+		t.Errorf("Unexpected buffer length %d, expected 0", len(e.buffer))
+	}
+	if len(r.requests) != 2 {
+		t.Fatalf("Unexpected requests %d, expected 2", len(r.requests))
+	}
+	for i, expected := range []string{"1700000000000000000", "1700000060000000000"} {
+		point := r.requests[i].ResourceMetrics[0].ScopeMetrics[0].Metrics[0].Sum.DataPoints[0]
+		if point.TimeUnixNano != expected {
+			t.Errorf("Unexpected time %s in request %d, expected %s", point.TimeUnixNano, i, expected)
+		}
+	}
+}
+
+func TestPushBufferFull(t *testing.T) {
+	r := &receiver{statuses: make([]int, 6)}
+	for i := range r.statuses {
+		r.statuses[i] = http.StatusBadGateway
+	}
+	server := httptest.NewServer(r)
+	defer server.Close()
+	e := testExporter(server.URL)
+	for i := 0; i < 3; i++ {
+		_ = e.Push(context.Background(), testBatches(t), time.Unix(int64(1700000000+i*60), 0))
+	}
+	if len(e.buffer) != 2 {
+		t.Errorf("Unexpected buffer length %d, expected 2", len(e.buffer))
+	}
+}
+
+func TestPushRejected(t *testing.T) {
+	r := &receiver{statuses: []int{http.StatusBadRequest}}
+	server := httptest.NewServer(r)
+	defer server.Close()
+	e := testExporter(server.URL)
+	if err := e.Push(context.Background(), testBatches(t), time.Unix(1700000000, 0)); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(e.buffer) != 0 || len(r.requests) != 0 {
+		t.Errorf("Unexpected rejected push kept, buffer %d requests %d", len(e.buffer), len(r.requests))
+	}
+}
+
+func TestEncodeNonFinite(t *testing.T) {
+	name := "eseries_drive_outlier_score"
+	value := math.Inf(1)
+	families := []*dto.MetricFamily{{
+		Name:   &name,
+		Type:   dto.MetricType_GAUGE.Enum(),
+		Metric: []*dto.Metric{{Gauge: &dto.Gauge{Value: &value}}},
+	}}
+	body, err := encode([]Batch{{Families: families}}, nil, time.Unix(1700000000, 0))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !strings.Contains(string(body), `"asDouble":"Infinity"`) {
+		t.Errorf("Unexpected encoding %s", body)
+	}
+}